@@ -0,0 +1,45 @@
+package split_openfeature_provider_go
+
+import (
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// ContextMapper maps an OpenFeature evaluation context to the key and attributes the Split SDK
+// evaluates and tracks with. Map must be safe for concurrent use. Returning an empty key makes
+// evaluations resolve with TARGETING_KEY_MISSING and Track calls be dropped, unless WithDerivedKeys
+// derives a key, which is then used with the returned attributes. Returned attributes must not be
+// modified afterwards.
+type ContextMapper interface {
+	Map(flatCtx openfeature.FlattenedContext) (key string, attributes map[string]interface{})
+}
+
+// ContextMapperFunc adapts an ordinary function to a ContextMapper.
+type ContextMapperFunc func(flatCtx openfeature.FlattenedContext) (key string, attributes map[string]interface{})
+
+// Map calls f(flatCtx).
+func (f ContextMapperFunc) Map(flatCtx openfeature.FlattenedContext) (string, map[string]interface{}) {
+	return f(flatCtx)
+}
+
+// DefaultContextMapper returns the mapper used when no WithContextMapper option is given: the
+// targeting key is the Split key and every other entry is passed as an attribute.
+func DefaultContextMapper() ContextMapper {
+	return defaultContextMapper{}
+}
+
+type defaultContextMapper struct{}
+
+func (defaultContextMapper) Map(flatCtx openfeature.FlattenedContext) (string, map[string]interface{}) {
+	return splitKeyAndAttributes(flatCtx)
+}
+
+// WithContextMapper replaces the default mapping from evaluation contexts to Split keys and
+// attributes, e.g. to take the key from an account attribute, build composite keys or add derived
+// attributes. The mapper also applies to Track and, when set, the provider's validation hook checks
+// the mapped key and attributes instead of the raw evaluation context, so contexts without a
+// targeting key are accepted as long as the mapper produces a key.
+func WithContextMapper(mapper ContextMapper) Option {
+	return func(o *providerOptions) {
+		o.contextMapper = mapper
+	}
+}
@@ -0,0 +1,93 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// accountMapper uses the accountId attribute as the Split key when present.
+var accountMapper = ContextMapperFunc(func(flatCtx openfeature.FlattenedContext) (string, map[string]interface{}) {
+	key, attrs := DefaultContextMapper().Map(flatCtx)
+	if account, ok := flatCtx["accountId"].(string); ok && account != "" {
+		key = account
+		if attrs != nil {
+			delete(attrs, "accountId")
+		}
+	}
+	return key, attrs
+})
+
+func TestDefaultContextMapper(t *testing.T) {
+	key, attrs := DefaultContextMapper().Map(openfeature.FlattenedContext{openfeature.TargetingKey: "user", "plan": "pro"})
+	if key != "user" || !reflect.DeepEqual(attrs, map[string]interface{}{"plan": "pro"}) {
+		t.Errorf("Unexpected mapping %q %v", key, attrs)
+	}
+	if key, _ := DefaultContextMapper().Map(openfeature.FlattenedContext{"plan": "pro"}); key != "" {
+		t.Errorf("Expected no key without a targeting key, got %q", key)
+	}
+}
+
+func TestWithContextMapper_Evaluation(t *testing.T) {
+	provider, err := NewLocalhostProviderFromDefinitions([]LocalFlag{
+		{Name: "checkout", Treatment: "on", Keys: []string{"acct-1"}},
+		{Name: "checkout", Treatment: "off"},
+	}, quietSDK(), WithContextMapper(accountMapper))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+	ctx := context.Background()
+
+	result := provider.BooleanEvaluation(ctx, "checkout", false, openfeature.FlattenedContext{"accountId": "acct-1"})
+	if result.Value != true {
+		t.Errorf("Expected the account key to be used without a targeting key, got %+v", result)
+	}
+	result = provider.BooleanEvaluation(ctx, "checkout", true, openfeature.FlattenedContext{openfeature.TargetingKey: "user"})
+	if result.Value != false {
+		t.Errorf("Expected the targeting key to be used without an account, got %+v", result)
+	}
+	result = provider.BooleanEvaluation(ctx, "checkout", true, openfeature.FlattenedContext{"plan": "pro"})
+	if result.ResolutionDetail().ErrorCode != openfeature.TargetingKeyMissingCode {
+		t.Errorf("Expected %s when the mapper yields no key, got %+v", openfeature.TargetingKeyMissingCode, result)
+	}
+}
+
+func TestWithContextMapper_ValidationHook(t *testing.T) {
+	hook := &contextValidationHook{limits: DefaultContextLimits(), mapper: accountMapper}
+	hints := openfeature.NewHookHints(nil)
+
+	evalCtx := openfeature.NewEvaluationContext("", map[string]any{"accountId": "acct-1"})
+	if _, err := hook.Before(context.Background(), hookContextFor(evalCtx), hints); err != nil {
+		t.Errorf("Expected a context with a mapped key to be accepted, got %v", err)
+	}
+	evalCtx = openfeature.NewEvaluationContext("", map[string]any{"plan": "pro"})
+	_, err := hook.Before(context.Background(), hookContextFor(evalCtx), hints)
+	requireResolutionCode(t, err, openfeature.TargetingKeyMissingCode)
+}
+
+func TestWithContextMapper_DerivedAttributes(t *testing.T) {
+	var mapped map[string]interface{}
+	mapper := ContextMapperFunc(func(flatCtx openfeature.FlattenedContext) (string, map[string]interface{}) {
+		key, attrs := DefaultContextMapper().Map(flatCtx)
+		if attrs == nil {
+			attrs = make(map[string]interface{})
+		}
+		attrs["region"] = "eu"
+		mapped = attrs
+		return key, attrs
+	})
+	provider, err := NewLocalhostProviderFromDefinitions([]LocalFlag{{Name: "checkout", Treatment: "on"}},
+		quietSDK(), WithContextMapper(mapper))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+
+	provider.BooleanEvaluation(context.Background(), "checkout", false, openfeature.FlattenedContext{openfeature.TargetingKey: "user"})
+	if !reflect.DeepEqual(mapped, map[string]interface{}{"region": "eu"}) {
+		t.Errorf("Expected the derived attribute to be mapped, got %v", mapped)
+	}
+}
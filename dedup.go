@@ -0,0 +1,131 @@
+package split_openfeature_provider_go
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"time"
+)
+
+// errDedupNeedsManager is returned by NewProvider for WithEvaluationDedup without WithManager.
+var errDedupNeedsManager = errors.New("evaluation dedup needs the Split manager, use WithManager")
+
+// dedupSettings holds the settings given with WithEvaluationDedup.
+type dedupSettings struct {
+	ttl        time.Duration
+	maxEntries int
+}
+
+// WithEvaluationDedup makes the provider reuse the Split result of an evaluation for the same flag,
+// key and attributes for ttl instead of calling Split again, so polling loops do not generate an
+// impression per evaluation. Reused results report reason CACHED, like WithEvaluationCache. At most
+// maxEntries results are kept, dropping the oldest first. A result is only reused while the flag's
+// definition has the change number it was evaluated with, which the provider reads from the Split
+// manager on every evaluation, so flag changes take effect immediately in every mode. Providers built
+// with NewProvider therefore need WithManager. All results are also discarded when the provider swaps
+// Split clients or emits PROVIDER_CONFIGURATION_CHANGED.
+func WithEvaluationDedup(ttl time.Duration, maxEntries int) Option {
+	return func(o *providerOptions) {
+		o.dedup = &dedupSettings{ttl: ttl, maxEntries: maxEntries}
+	}
+}
+
+func (s *dedupSettings) validate() error {
+	if s == nil {
+		return nil
+	}
+	if s.ttl <= 0 {
+		return errors.New("evaluation dedup TTL must be positive")
+	}
+	if s.maxEntries <= 0 {
+		return errors.New("evaluation dedup max entries must be positive")
+	}
+	return nil
+}
+
+// dedupCache holds recent Split results across evaluations, in insertion order so that the oldest
+// entry is both the first to expire and the first to be evicted.
+type dedupCache struct {
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu sync.Mutex
+	// generation is increased by invalidate so that results obtained before an invalidation are
+	// not stored after it.
+	generation uint64
+	entries    map[evaluationCacheKey]*list.Element
+	order      *list.List
+}
+
+type dedupEntry struct {
+	key    evaluationCacheKey
+	result splitResult
+	// changeNumber is the change number of the flag's definition when result was evaluated.
+	changeNumber int64
+	expires      time.Time
+}
+
+func newDedupCache(settings *dedupSettings) *dedupCache {
+	if settings == nil {
+		return nil
+	}
+	return &dedupCache{
+		ttl:        settings.ttl,
+		maxEntries: settings.maxEntries,
+		now:        time.Now,
+		entries:    make(map[evaluationCacheKey]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// get returns the result stored for k, if it has not expired and was evaluated with changeNumber,
+// and the generation to pass to put.
+func (c *dedupCache) get(k evaluationCacheKey, changeNumber int64) (splitResult, uint64, bool) {
+	now := c.now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[k]; ok {
+		entry := elem.Value.(*dedupEntry)
+		if now.Before(entry.expires) && entry.changeNumber == changeNumber {
+			return entry.result, c.generation, true
+		}
+		c.remove(elem)
+	}
+	return splitResult{}, c.generation, false
+}
+
+// put stores result for k, evaluated with changeNumber, unless the cache was invalidated since
+// generation was returned by get.
+func (c *dedupCache) put(k evaluationCacheKey, result splitResult, changeNumber int64, generation uint64) {
+	now := c.now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	if elem, ok := c.entries[k]; ok {
+		c.remove(elem)
+	}
+	c.entries[k] = c.order.PushBack(&dedupEntry{key: k, result: result, changeNumber: changeNumber, expires: now.Add(c.ttl)})
+	for c.order.Len() > c.maxEntries {
+		c.remove(c.order.Front())
+	}
+}
+
+// invalidate discards every stored result.
+func (c *dedupCache) invalidate() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	c.entries = make(map[evaluationCacheKey]*list.Element)
+	c.order.Init()
+}
+
+func (c *dedupCache) remove(elem *list.Element) {
+	delete(c.entries, elem.Value.(*dedupEntry).key)
+	c.order.Remove(elem)
+}
@@ -0,0 +1,190 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+func TestDedupCache(t *testing.T) {
+	cache := newDedupCache(&dedupSettings{ttl: time.Minute, maxEntries: 2})
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	keyA := evaluationCacheKey{flag: "a", key: "user"}
+	keyB := evaluationCacheKey{flag: "b", key: "user"}
+	keyC := evaluationCacheKey{flag: "c", key: "user"}
+
+	_, gen, ok := cache.get(keyA, 1)
+	if ok {
+		t.Fatal("Expected an empty cache")
+	}
+	cache.put(keyA, splitResult{treatment: "on"}, 1, gen)
+	if result, _, ok := cache.get(keyA, 1); !ok || result.treatment != "on" {
+		t.Errorf("Expected the stored result, got %+v %v", result, ok)
+	}
+
+	cache.put(keyB, splitResult{treatment: "on"}, 1, gen)
+	cache.put(keyC, splitResult{treatment: "on"}, 1, gen)
+	if _, _, ok := cache.get(keyA, 1); ok {
+		t.Error("Expected the oldest entry to be evicted past max entries")
+	}
+
+	now = now.Add(time.Minute)
+	if _, _, ok := cache.get(keyB, 1); ok {
+		t.Error("Expected the entry to expire after the TTL")
+	}
+	if cache.order.Len() != 1 {
+		t.Errorf("Expected the expired entry to be removed, %d left", cache.order.Len())
+	}
+}
+
+func TestDedupCache_ChangeNumber(t *testing.T) {
+	cache := newDedupCache(&dedupSettings{ttl: time.Minute, maxEntries: 10})
+	k := evaluationCacheKey{flag: "a", key: "user"}
+
+	_, gen, _ := cache.get(k, 1)
+	cache.put(k, splitResult{treatment: "on"}, 1, gen)
+	if _, _, ok := cache.get(k, 2); ok {
+		t.Error("Expected a result evaluated with another change number not to be reused")
+	}
+	if _, _, ok := cache.get(k, 1); ok {
+		t.Error("Expected the outdated result to be removed")
+	}
+}
+
+func TestDedupCache_Invalidate(t *testing.T) {
+	cache := newDedupCache(&dedupSettings{ttl: time.Minute, maxEntries: 10})
+	k := evaluationCacheKey{flag: "a", key: "user"}
+
+	_, before, _ := cache.get(k, 1)
+	cache.put(k, splitResult{treatment: "on"}, 1, before)
+	cache.invalidate()
+	if _, _, ok := cache.get(k, 1); ok {
+		t.Error("Expected invalidate to discard results")
+	}
+	cache.put(k, splitResult{treatment: "on"}, 1, before)
+	if _, _, ok := cache.get(k, 1); ok {
+		t.Error("Expected a result obtained before the invalidation not to be stored")
+	}
+}
+
+func TestWithEvaluationDedup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "split.yaml")
+	if err := os.WriteFile(path, []byte(watchedSplitFile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	provider, err := NewLocalhostProvider(path, quietSDK(), WithLocalhostFileWatch(20*time.Millisecond),
+		WithEvaluationDedup(time.Hour, 100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+	ctx := context.Background()
+	user := openfeature.FlattenedContext{openfeature.TargetingKey: "user", "plan": "pro"}
+
+	if result := provider.BooleanEvaluation(ctx, "feature_a", false, user); result.Value != true || result.Reason != openfeature.TargetingMatchReason {
+		t.Fatalf("Expected feature_a on from Split, got %+v", result)
+	}
+	if result := provider.BooleanEvaluation(ctx, "feature_a", false, user); result.Reason != openfeature.CachedReason {
+		t.Errorf("Expected the repeated evaluation to be reused, got %+v", result)
+	}
+	other := openfeature.FlattenedContext{openfeature.TargetingKey: "user", "plan": "free"}
+	if result := provider.BooleanEvaluation(ctx, "feature_a", false, other); result.Reason == openfeature.CachedReason {
+		t.Errorf("Expected different attributes not to be reused, got %+v", result)
+	}
+
+	if err := os.WriteFile(path, []byte(watchedSplitFileUpdated), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	waitForEvent(t, provider.EventChannel())
+	if result := provider.BooleanEvaluation(ctx, "feature_a", true, user); result.Value != false || result.Reason == openfeature.CachedReason {
+		t.Errorf("Expected the configuration change to invalidate reused results, got %+v", result)
+	}
+}
+
+func TestWithEvaluationDedup_ReloadWithoutChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "split.yaml")
+	if err := os.WriteFile(path, []byte(watchedSplitFile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	provider, err := NewLocalhostProvider(path, quietSDK(), WithLocalhostFileWatch(20*time.Millisecond),
+		WithEvaluationDedup(time.Hour, 100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+	provider.BooleanEvaluation(context.Background(), "feature_a", false, openfeature.FlattenedContext{openfeature.TargetingKey: "user"})
+
+	// Same definitions, different bytes: the client is swapped without a configuration change.
+	if err := os.WriteFile(path, []byte(watchedSplitFile+"\n# reformatted\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for provider.dedupEntries() > 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the client swap to discard reused results")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func (p *SplitProvider) dedupEntries() int {
+	p.dedup.mu.Lock()
+	defer p.dedup.mu.Unlock()
+	return p.dedup.order.Len()
+}
+
+func TestWithEvaluationDedup_RedisConsumer(t *testing.T) {
+	server := miniredis.RunT(t)
+	synchronize(t, server, "myapp")
+	provider, err := NewRedisConsumerProvider(context.Background(), "api-key", redisConfigFor(t, server, "myapp"),
+		quietSDK(), WithEvaluationDedup(time.Hour, 100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+	ctx := context.Background()
+	user := openfeature.FlattenedContext{openfeature.TargetingKey: "user"}
+
+	provider.StringEvaluation(ctx, "checkout", "", user)
+	if result := provider.StringEvaluation(ctx, "checkout", "", user); result.Value != "v1" || result.Reason != openfeature.CachedReason {
+		t.Fatalf("Expected v1 to be reused, got %+v", result)
+	}
+
+	updated := strings.NewReplacer(`"defaultTreatment": "v1", "changeNumber": 1`, `"defaultTreatment": "v2", "changeNumber": 2`).Replace(redisCheckoutFlag)
+	if err := server.Set("myapp.SPLITIO.split.checkout", updated); err != nil {
+		t.Fatal(err)
+	}
+	if result := provider.StringEvaluation(ctx, "checkout", "", user); result.Value != "v2" || result.Reason == openfeature.CachedReason {
+		t.Errorf("Expected the changed flag to be evaluated again, got %+v", result)
+	}
+}
+
+func TestWithEvaluationDedup_NeedsManager(t *testing.T) {
+	localhost, err := NewLocalhostProviderFromDefinitions([]LocalFlag{{Name: "checkout", Treatment: "on"}}, quietSDK())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer localhost.Shutdown()
+	if _, err := NewProvider(localhost.currentClient(), WithEvaluationDedup(time.Hour, 100)); !errors.Is(err, errDedupNeedsManager) {
+		t.Errorf("Expected %v, got %v", errDedupNeedsManager, err)
+	}
+	if _, err := NewProvider(localhost.currentClient(), WithEvaluationDedup(time.Hour, 100), WithManager(localhost.currentManager())); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWithEvaluationDedup_Invalid(t *testing.T) {
+	for _, opt := range []Option{WithEvaluationDedup(0, 10), WithEvaluationDedup(time.Second, 0)} {
+		if _, err := newProviderOptions([]Option{opt}); err == nil {
+			t.Error("Expected an error")
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package split_openfeature_provider_go
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// derivedKeyMetadataKey is the FlagMetadata key reporting how the key of an evaluation was derived.
+const derivedKeyMetadataKey = "derivedKey"
+
+// KeyDeriver derives a Split key for evaluation contexts without one. It returns the key and a short
+// description of where it came from, reported in FlagMetadata["derivedKey"], or an empty key when it
+// cannot derive one. It must be safe for concurrent use.
+type KeyDeriver func(flatCtx openfeature.FlattenedContext) (key string, source string)
+
+// AnonymousKey returns a KeyDeriver that uses key for every context without one, so all anonymous
+// traffic shares a single bucket. The source is "anonymous".
+func AnonymousKey(key string) KeyDeriver {
+	return func(openfeature.FlattenedContext) (string, string) {
+		return key, "anonymous"
+	}
+}
+
+// KeyFromAttribute returns a KeyDeriver that uses the first of the named attributes holding a
+// non-empty string, e.g. a session or device ID. The source is "attribute:<name>".
+func KeyFromAttribute(names ...string) KeyDeriver {
+	names = append([]string(nil), names...)
+	return func(flatCtx openfeature.FlattenedContext) (string, string) {
+		for _, name := range names {
+			if key, _ := flatCtx[name].(string); key != "" {
+				return key, "attribute:" + name
+			}
+		}
+		return "", ""
+	}
+}
+
+// HashedKey returns a KeyDeriver that hashes the named attributes into a stable key, so contexts
+// with the same values for them are bucketed alike. Missing attributes are left out; when none is
+// present no key is derived. The source is "hash".
+func HashedKey(names ...string) KeyDeriver {
+	names = append([]string(nil), names...)
+	return func(flatCtx openfeature.FlattenedContext) (string, string) {
+		present := make(map[string]interface{}, len(names))
+		for _, name := range names {
+			if value, ok := flatCtx[name]; ok && value != nil {
+				present[name] = value
+			}
+		}
+		if len(present) == 0 {
+			return "", ""
+		}
+		sum := sha256.Sum256([]byte(canonicalAttributes(present)))
+		return hex.EncodeToString(sum[:16]), "hash"
+	}
+}
+
+// WithDerivedKeys makes the provider derive a key with deriver for contexts that have none, instead
+// of failing with TARGETING_KEY_MISSING. It applies to evaluations, Track and context validation,
+// after the ContextMapper; successful evaluations with a derived key report its source in
+// FlagMetadata["derivedKey"]. Contexts the deriver finds no key for still fail.
+func WithDerivedKeys(deriver KeyDeriver) Option {
+	return func(o *providerOptions) {
+		o.keyDeriver = deriver
+	}
+}
+
+// derive returns the key deriver derives for flatCtx, or "" when deriver is nil or finds none.
+func (deriver KeyDeriver) derive(flatCtx openfeature.FlattenedContext) (string, string) {
+	if deriver == nil {
+		return "", ""
+	}
+	return deriver(flatCtx)
+}
+
+// withDerivedKey returns metadata with the source of a derived key added, leaving metadata, which
+// may be shared, untouched.
+func withDerivedKey(metadata openfeature.FlagMetadata, source string) openfeature.FlagMetadata {
+	derived := make(openfeature.FlagMetadata, len(metadata)+1)
+	for k, v := range metadata {
+		derived[k] = v
+	}
+	derived[derivedKeyMetadataKey] = source
+	return derived
+}
@@ -0,0 +1,132 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+func TestKeyDerivers(t *testing.T) {
+	flatCtx := openfeature.FlattenedContext{"sessionId": "s-1", "deviceId": "d-1", "country": "PT", "age": 30}
+
+	if key, source := AnonymousKey("anon").derive(flatCtx); key != "anon" || source != "anonymous" {
+		t.Errorf("Unexpected anonymous key %q %q", key, source)
+	}
+	if key, source := KeyFromAttribute("userId", "deviceId", "sessionId").derive(flatCtx); key != "d-1" || source != "attribute:deviceId" {
+		t.Errorf("Expected the first present attribute, got %q %q", key, source)
+	}
+	if key, _ := KeyFromAttribute("age").derive(flatCtx); key != "" {
+		t.Errorf("Expected non-string attributes to be ignored, got %q", key)
+	}
+
+	hashed := HashedKey("country", "age", "missing")
+	key, source := hashed.derive(flatCtx)
+	if key == "" || source != "hash" {
+		t.Fatalf("Unexpected hashed key %q %q", key, source)
+	}
+	if again, _ := HashedKey("missing", "age", "country").derive(openfeature.FlattenedContext{"country": "PT", "age": 30}); again != key {
+		t.Errorf("Expected a stable hash regardless of name order and other attributes, got %q and %q", key, again)
+	}
+	if other, _ := hashed.derive(openfeature.FlattenedContext{"country": "PT", "age": "30"}); other == key {
+		t.Error("Expected values of different types to hash differently")
+	}
+	groups := HashedKey("groups")
+	spaced, _ := groups.derive(openfeature.FlattenedContext{"groups": []string{"beta testers"}})
+	if split, _ := groups.derive(openfeature.FlattenedContext{"groups": []string{"beta", "testers"}}); spaced == split {
+		t.Error("Expected different attribute values to derive different keys")
+	}
+	if none, _ := hashed.derive(openfeature.FlattenedContext{"plan": "pro"}); none != "" {
+		t.Errorf("Expected no key without the hashed attributes, got %q", none)
+	}
+
+	var deriver KeyDeriver
+	if key, _ := deriver.derive(flatCtx); key != "" {
+		t.Errorf("Expected no key from a nil deriver, got %q", key)
+	}
+}
+
+func TestWithDerivedKeys_Evaluation(t *testing.T) {
+	provider, err := NewLocalhostProviderFromDefinitions([]LocalFlag{
+		{Name: "checkout", Treatment: "on", Keys: []string{"s-1"}},
+		{Name: "checkout", Treatment: "off", Config: `{"color":"grey"}`},
+	}, quietSDK(), WithDerivedKeys(KeyFromAttribute("sessionId")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+	ctx := context.Background()
+
+	result := provider.BooleanEvaluation(ctx, "checkout", false, openfeature.FlattenedContext{"sessionId": "s-1"})
+	if result.Value != true || result.FlagMetadata[derivedKeyMetadataKey] != "attribute:sessionId" {
+		t.Errorf("Expected the session key to be used and reported, got %+v", result)
+	}
+	result = provider.BooleanEvaluation(ctx, "checkout", true, openfeature.FlattenedContext{"sessionId": "s-2"})
+	if result.Value != false || result.FlagMetadata[flagMetadataConfigKey] != `{"color":"grey"}` || result.FlagMetadata[derivedKeyMetadataKey] == nil {
+		t.Errorf("Expected the config to be kept next to the derived key, got %+v", result)
+	}
+	if shared := provider.metadata.forConfig(stringPtr(`{"color":"grey"}`)); shared[derivedKeyMetadataKey] != nil {
+		t.Error("Expected the shared config metadata to be left untouched")
+	}
+
+	result = provider.BooleanEvaluation(ctx, "checkout", false, openfeature.FlattenedContext{openfeature.TargetingKey: "s-1", "sessionId": "s-2"})
+	if result.Value != true || result.FlagMetadata[derivedKeyMetadataKey] != nil {
+		t.Errorf("Expected the targeting key to win over derivation, got %+v", result)
+	}
+	result = provider.BooleanEvaluation(ctx, "checkout", false, openfeature.FlattenedContext{"plan": "pro"})
+	if result.ResolutionDetail().ErrorCode != openfeature.TargetingKeyMissingCode {
+		t.Errorf("Expected %s when no key can be derived, got %+v", openfeature.TargetingKeyMissingCode, result)
+	}
+}
+
+// redisPlanFlag serves "on" to contexts whose plan attribute is "pro".
+const redisPlanFlag = `{"name": "checkout", "trafficTypeName": "user", "status": "ACTIVE", "killed": false,
+ "defaultTreatment": "off", "changeNumber": 1, "algo": 2, "seed": 1, "trafficAllocation": 100,
+ "trafficAllocationSeed": 1, "conditions": [{"conditionType": "ROLLOUT", "label": "pro plan",
+ "matcherGroup": {"combiner": "AND", "matchers": [{"keySelector": {"trafficType": "user", "attribute": "plan"},
+ "matcherType": "WHITELIST", "negate": false, "whitelistMatcherData": {"whitelist": ["pro"]}}]},
+ "partitions": [{"treatment": "on", "size": 100}]}]}`
+
+func TestWithDerivedKeys_Attributes(t *testing.T) {
+	server := miniredis.RunT(t)
+	synchronize(t, server, "myapp")
+	if err := server.Set("myapp.SPLITIO.split.checkout", redisPlanFlag); err != nil {
+		t.Fatal(err)
+	}
+	provider, err := NewRedisConsumerProvider(context.Background(), "api-key", redisConfigFor(t, server, "myapp"),
+		quietSDK(), WithDerivedKeys(KeyFromAttribute("sessionId")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+
+	in, _, ok := provider.mapContext(openfeature.FlattenedContext{"sessionId": "s-1", "plan": "pro"})
+	if !ok || in.key != "s-1" || in.attrs["plan"] != "pro" {
+		t.Fatalf("Expected the derived key with the context attributes, got %+v", in)
+	}
+	result := provider.BooleanEvaluation(context.Background(), "checkout", false, openfeature.FlattenedContext{"sessionId": "s-1", "plan": "pro"})
+	if result.Value != true {
+		t.Errorf("Expected the attribute rule to match for a derived key, got %+v", result)
+	}
+}
+
+func TestWithDerivedKeys_ValidationHook(t *testing.T) {
+	hook := &contextValidationHook{limits: DefaultContextLimits(), deriver: AnonymousKey("anonymous")}
+	evalCtx := openfeature.NewTargetlessEvaluationContext(map[string]any{"plan": "pro"})
+	if _, err := hook.Before(context.Background(), hookContextFor(evalCtx), openfeature.NewHookHints(nil)); err != nil {
+		t.Errorf("Expected a context without key to be accepted, got %v", err)
+	}
+
+	hook.limits.MaxAttributeSize = 3
+	evalCtx = openfeature.NewTargetlessEvaluationContext(map[string]any{"plan": "enterprise"})
+	_, err := hook.Before(context.Background(), hookContextFor(evalCtx), openfeature.NewHookHints(nil))
+	if err == nil || !strings.Contains(err.Error(), "plan") {
+		t.Errorf("Expected the attributes of a context with a derived key to be validated, got %v", err)
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
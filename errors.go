@@ -3,3 +3,10 @@ package split_openfeature_provider_go
 import "errors"
 
 var errNilSplitClient = errors.New("Split client cannot be nil")
+
+// ErrNoSplitManager is returned by the introspection APIs when the provider has no Split manager.
+// Providers built with NewProvider need the WithManager option for them.
+var ErrNoSplitManager = errors.New("Split manager not available, use WithManager")
+
+// ErrFlagNotFound is returned when a flag does not exist in the Split definitions.
+var ErrFlagNotFound = errors.New("flag not found")
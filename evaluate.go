@@ -0,0 +1,264 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// TreatmentDecoder converts Split treatments into flag values of type T. Decode returns an error for
+// treatments that are not valid values, which makes the evaluation fail with PARSE_ERROR.
+type TreatmentDecoder[T any] interface {
+	Decode(treatment string) (T, error)
+}
+
+// TreatmentDecoderFunc adapts an ordinary function to a TreatmentDecoder.
+type TreatmentDecoderFunc[T any] func(treatment string) (T, error)
+
+// Decode calls f(treatment).
+func (f TreatmentDecoderFunc[T]) Decode(treatment string) (T, error) {
+	return f(treatment)
+}
+
+// Decoders of the *Evaluation methods, also registered for Evaluate.
+var (
+	booleanDecoder TreatmentDecoder[bool]    = TreatmentDecoderFunc[bool](parseBooleanTreatment)
+	stringDecoder  TreatmentDecoder[string]  = TreatmentDecoderFunc[string](func(treatment string) (string, error) { return treatment, nil })
+	floatDecoder   TreatmentDecoder[float64] = TreatmentDecoderFunc[float64](parseFloatTreatment)
+	intDecoder     TreatmentDecoder[int64]   = TreatmentDecoderFunc[int64](parseIntTreatment)
+	objectDecoder  TreatmentDecoder[any]     = jsonObjectDecoder[any](func(object map[string]any) any { return object })
+)
+
+// jsonObjectDecoder decodes JSON object treatments and converts them to T. resolve recognizes it and
+// parses treatments through the provider's parsedCache instead of calling Decode.
+type jsonObjectDecoder[T any] func(object map[string]any) T
+
+// Decode parses treatment as a JSON object and converts it.
+func (convert jsonObjectDecoder[T]) Decode(treatment string) (T, error) {
+	object, err := parseObjectTreatment(treatment)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return convert(object), nil
+}
+
+// WithTreatmentDecoder registers decoder for Evaluate[T], e.g. to read enums, versions or
+// durations from treatments. bool, string, float64, int64, map[string]any and any (JSON objects)
+// have decoders by default, following the rules of the *Evaluation methods; registering another one
+// for them only changes Evaluate, never the *Evaluation methods.
+func WithTreatmentDecoder[T any](decoder TreatmentDecoder[T]) Option {
+	return func(o *providerOptions) {
+		if o.decoders == nil {
+			o.decoders = make(map[reflect.Type]any)
+		}
+		o.decoders[reflect.TypeFor[T]()] = decoder
+	}
+}
+
+// defaultDecoders returns the decoders Evaluate uses when none is registered for the type.
+func defaultDecoders() map[reflect.Type]any {
+	return map[reflect.Type]any{
+		reflect.TypeFor[bool]():           booleanDecoder,
+		reflect.TypeFor[string]():         stringDecoder,
+		reflect.TypeFor[float64]():        floatDecoder,
+		reflect.TypeFor[int64]():          intDecoder,
+		reflect.TypeFor[map[string]any](): jsonObjectDecoder[map[string]any](func(object map[string]any) map[string]any { return object }),
+		reflect.TypeFor[any]():            objectDecoder,
+	}
+}
+
+func validateDecoders(decoders map[reflect.Type]any) error {
+	for t, decoder := range decoders {
+		v := reflect.ValueOf(decoder)
+		if !v.IsValid() || (v.Kind() == reflect.Func || v.Kind() == reflect.Pointer) && v.IsNil() {
+			return fmt.Errorf("treatment decoder for %s cannot be nil", t)
+		}
+	}
+	return nil
+}
+
+// Evaluate resolves flag as a value of type T with the TreatmentDecoder registered for T (see
+// WithTreatmentDecoder), the same way the *Evaluation methods do for their types: context mapping,
+// caches, fallbacks and evaluation records all apply. Evaluate is called on the provider directly
+// rather than through an OpenFeature client, so it runs the provider's own hooks (context validation
+// and exposure tracking) around the evaluation itself; API, client and invocation hooks do not run.
+// Without a decoder for T the evaluation fails with TYPE_MISMATCH.
+func Evaluate[T any](ctx context.Context, p *SplitProvider, flag string, defaultValue T, evalCtx openfeature.EvaluationContext) (detail openfeature.GenericResolutionDetail[T]) {
+	hooks := p.Hooks()
+	if len(hooks) == 0 {
+		return evaluate(ctx, p, flag, defaultValue, flattenEvaluationContext(evalCtx))
+	}
+	flagType := flagTypeOf[T]()
+	hints := openfeature.NewHookHints(nil)
+	hookContext := openfeature.NewHookContext(flag, flagType, defaultValue, openfeature.NewClientMetadata(""), p.Metadata(), evalCtx)
+	evaluationDetails := func() openfeature.InterfaceEvaluationDetails {
+		return openfeature.InterfaceEvaluationDetails{
+			Value: detail.Value,
+			EvaluationDetails: openfeature.EvaluationDetails{
+				FlagKey:          flag,
+				FlagType:         flagType,
+				ResolutionDetail: detail.ResolutionDetail(),
+			},
+		}
+	}
+	defer func() {
+		for i := len(hooks) - 1; i >= 0; i-- {
+			hooks[i].Finally(ctx, hookContext, evaluationDetails(), hints)
+		}
+	}()
+	fail := func(err error) {
+		for i := len(hooks) - 1; i >= 0; i-- {
+			hooks[i].Error(ctx, hookContext, err, hints)
+		}
+	}
+
+	for _, hook := range hooks {
+		hooked, err := hook.Before(ctx, hookContext, hints)
+		if hooked != nil {
+			hookContext = openfeature.NewHookContext(flag, flagType, defaultValue, hookContext.ClientMetadata(), hookContext.ProviderMetadata(),
+				mergeEvaluationContexts(*hooked, evalCtx))
+		}
+		if err != nil {
+			resolutionErr, ok := err.(openfeature.ResolutionError)
+			if !ok {
+				resolutionErr = openfeature.NewGeneralResolutionError(err.Error())
+			}
+			detail = openfeature.GenericResolutionDetail[T]{
+				Value: defaultValue,
+				ProviderResolutionDetail: openfeature.ProviderResolutionDetail{
+					ResolutionError: resolutionErr,
+					Reason:          openfeature.ErrorReason,
+				},
+			}
+			fail(err)
+			return detail
+		}
+	}
+	detail = evaluate(ctx, p, flag, defaultValue, flattenEvaluationContext(hookContext.EvaluationContext()))
+	if err := detail.Error(); err != nil {
+		fail(err)
+		return detail
+	}
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := hooks[i].After(ctx, hookContext, evaluationDetails(), hints); err != nil {
+			fail(err)
+			break
+		}
+	}
+	return detail
+}
+
+// evaluate resolves flag as a value of type T for flatCtx, as described for Evaluate.
+func evaluate[T any](ctx context.Context, p *SplitProvider, flag string, defaultValue T, flatCtx openfeature.FlattenedContext) openfeature.GenericResolutionDetail[T] {
+	decoder, ok := p.decoders[reflect.TypeFor[T]()].(TreatmentDecoder[T])
+	if !ok {
+		return openfeature.GenericResolutionDetail[T]{
+			Value: defaultValue,
+			ProviderResolutionDetail: openfeature.ProviderResolutionDetail{
+				ResolutionError: openfeature.NewTypeMismatchResolutionError(fmt.Sprintf("no treatment decoder for %s", reflect.TypeFor[T]())),
+				Reason:          openfeature.ErrorReason,
+			},
+		}
+	}
+	return resolve(ctx, p, flagTypeOf[T](), flag, defaultValue, flatCtx, decoder)
+}
+
+// mergeEvaluationContexts returns primary with the targeting key and attributes it lacks taken from
+// fallback, as the OpenFeature SDK merges the contexts returned by Before hooks.
+func mergeEvaluationContexts(primary, fallback openfeature.EvaluationContext) openfeature.EvaluationContext {
+	key := primary.TargetingKey()
+	if key == "" {
+		key = fallback.TargetingKey()
+	}
+	attrs := primary.Attributes()
+	if attrs == nil {
+		attrs = make(map[string]any)
+	}
+	for name, value := range fallback.Attributes() {
+		if _, ok := attrs[name]; !ok {
+			attrs[name] = value
+		}
+	}
+	return openfeature.NewEvaluationContext(key, attrs)
+}
+
+// flagTypeOf returns the OpenFeature type evaluation records report for T.
+func flagTypeOf[T any]() openfeature.Type {
+	switch any(*new(T)).(type) {
+	case bool:
+		return openfeature.Boolean
+	case string:
+		return openfeature.String
+	case float64:
+		return openfeature.Float
+	case int64:
+		return openfeature.Int
+	}
+	return openfeature.Object
+}
+
+// resolve is the evaluation shared by the *Evaluation methods and Evaluate: it maps the context,
+// resolves the treatment and decodes it, falling back to defaultValue with the matching error.
+func resolve[T any](ctx context.Context, p *SplitProvider, flagType openfeature.Type, flag string, defaultValue T, flatCtx openfeature.FlattenedContext, decoder TreatmentDecoder[T]) (detail openfeature.GenericResolutionDetail[T]) {
+	trace := p.recorder.start(flagType, flag)
+	if trace != nil {
+		defer func() { trace.finish(defaultValue, detail.Value, detail.ProviderResolutionDetail) }()
+	}
+	in, failure, ok := p.mapContext(flatCtx)
+	if !ok {
+		return openfeature.GenericResolutionDetail[T]{
+			Value:                    defaultValue,
+			ProviderResolutionDetail: failure,
+		}
+	}
+	trace.setKey(in.key)
+	result := p.evaluateTreatmentWithConfig(ctx, flag, in, evaluationOptions(ctx, flatCtx), trace)
+	treatment := result.treatment
+	if noTreatment(treatment) {
+		return openfeature.GenericResolutionDetail[T]{
+			Value:                    defaultValue,
+			ProviderResolutionDetail: detailFlagNotFound(treatment),
+		}
+	}
+	if failure, rejected := p.variants.check(flag, treatment); rejected {
+		return openfeature.GenericResolutionDetail[T]{
+			Value:                    defaultValue,
+			ProviderResolutionDetail: failure,
+		}
+	}
+	if failure, rejected := p.checkSchemas(flag, result); rejected {
+		return openfeature.GenericResolutionDetail[T]{
+			Value:                    defaultValue,
+			ProviderResolutionDetail: failure,
+		}
+	}
+	value, err := decodeTreatment(p, flag, treatment, decoder)
+	if err != nil {
+		return openfeature.GenericResolutionDetail[T]{
+			Value:                    defaultValue,
+			ProviderResolutionDetail: detailParseError(treatment),
+		}
+	}
+	return openfeature.GenericResolutionDetail[T]{
+		Value:                    value,
+		ProviderResolutionDetail: detailSuccess(result),
+	}
+}
+
+// decodeTreatment decodes treatment with decoder, serving JSON objects from the provider's
+// parsedCache.
+func decodeTreatment[T any](p *SplitProvider, flag, treatment string, decoder TreatmentDecoder[T]) (T, error) {
+	convert, ok := decoder.(jsonObjectDecoder[T])
+	if !ok {
+		return decoder.Decode(treatment)
+	}
+	object, err := p.parsed.object(flag, treatment)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return convert(object), nil
+}
@@ -0,0 +1,139 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+type checkoutVersion int
+
+const (
+	checkoutV1 checkoutVersion = iota + 1
+	checkoutV2
+)
+
+var checkoutVersionDecoder = TreatmentDecoderFunc[checkoutVersion](func(treatment string) (checkoutVersion, error) {
+	switch treatment {
+	case "v1":
+		return checkoutV1, nil
+	case "v2":
+		return checkoutV2, nil
+	}
+	return 0, fmt.Errorf("unknown checkout version %q", treatment)
+})
+
+func TestEvaluate_CustomDecoders(t *testing.T) {
+	var records []EvaluationRecord
+	provider, err := NewLocalhostProviderFromDefinitions([]LocalFlag{
+		{Name: "checkout", Treatment: "v2", Keys: []string{"beta"}},
+		{Name: "checkout", Treatment: "v1"},
+		{Name: "timeout", Treatment: "1500ms"},
+		{Name: "broken", Treatment: "v9"},
+	}, quietSDK(),
+		WithTreatmentDecoder[checkoutVersion](checkoutVersionDecoder),
+		WithTreatmentDecoder[time.Duration](TreatmentDecoderFunc[time.Duration](time.ParseDuration)),
+		WithEvaluationRecorder(NewEvaluationRecorder(func(r EvaluationRecord) { records = append(records, r) })))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+	ctx := context.Background()
+	beta := openfeature.NewEvaluationContext("beta", nil)
+
+	version := Evaluate(ctx, provider, "checkout", checkoutV1, beta)
+	if version.Value != checkoutV2 || version.Variant != "v2" || version.Reason != openfeature.TargetingMatchReason {
+		t.Errorf("Expected checkout v2 for beta, got %+v", version)
+	}
+	timeout := Evaluate(ctx, provider, "timeout", time.Second, beta)
+	if timeout.Value != 1500*time.Millisecond {
+		t.Errorf("Expected a 1.5s timeout, got %+v", timeout)
+	}
+	broken := Evaluate(ctx, provider, "broken", checkoutV1, beta)
+	if broken.Value != checkoutV1 || broken.ResolutionDetail().ErrorCode != openfeature.ParseErrorCode {
+		t.Errorf("Expected %s for an unknown version, got %+v", openfeature.ParseErrorCode, broken)
+	}
+	missing := Evaluate(ctx, provider, "missing", checkoutV1, beta)
+	if missing.ResolutionDetail().ErrorCode != openfeature.FlagNotFoundCode {
+		t.Errorf("Expected %s, got %+v", openfeature.FlagNotFoundCode, missing)
+	}
+
+	if len(records) != 4 || records[0].FlagType != openfeature.Object || records[0].Value != checkoutV2 {
+		t.Errorf("Expected evaluation records for custom types, got %+v", records)
+	}
+}
+
+func TestEvaluate_DefaultDecoders(t *testing.T) {
+	provider, err := NewLocalhostProviderFromDefinitions([]LocalFlag{
+		{Name: "enabled", Treatment: "on"},
+		{Name: "limit", Treatment: "42"},
+		{Name: "settings", Treatment: `{"color":"blue"}`},
+	}, quietSDK())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+	ctx := context.Background()
+	user := openfeature.NewEvaluationContext("user", nil)
+
+	if result := Evaluate(ctx, provider, "enabled", false, user); result.Value != true {
+		t.Errorf("Expected true, got %+v", result)
+	}
+	if result := Evaluate(ctx, provider, "limit", int64(0), user); result.Value != 42 {
+		t.Errorf("Expected 42, got %+v", result)
+	}
+	if result := Evaluate(ctx, provider, "limit", "", user); result.Value != "42" {
+		t.Errorf("Expected \"42\", got %+v", result)
+	}
+	if result := Evaluate(ctx, provider, "settings", map[string]any(nil), user); result.Value["color"] != "blue" {
+		t.Errorf("Expected the decoded object, got %+v", result)
+	}
+	if result := Evaluate(ctx, provider, "limit", 0, user); result.ResolutionDetail().ErrorCode != openfeature.TypeMismatchCode {
+		t.Errorf("Expected %s without a decoder for int, got %+v", openfeature.TypeMismatchCode, result)
+	}
+}
+
+func TestWithTreatmentDecoder_Nil(t *testing.T) {
+	for _, opt := range []Option{
+		WithTreatmentDecoder[checkoutVersion](nil),
+		WithTreatmentDecoder[checkoutVersion](TreatmentDecoderFunc[checkoutVersion](nil)),
+	} {
+		if _, err := newProviderOptions([]Option{opt}); err == nil {
+			t.Error("Expected an error for a nil decoder")
+		}
+	}
+}
+
+func TestEvaluate_ProviderHooks(t *testing.T) {
+	provider, err := NewLocalhostProviderFromDefinitions([]LocalFlag{{Name: "checkout", Treatment: "v2"}}, quietSDK(),
+		WithTreatmentDecoder[checkoutVersion](checkoutVersionDecoder), WithContextValidation(),
+		WithExposureTracking(ExposureTracking{TrafficType: "user"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+	var exposed []string
+	for _, h := range provider.Hooks() {
+		if hook, ok := h.(*exposureHook); ok {
+			hook.track = func(key, trafficType, eventType string, value interface{}, properties map[string]interface{}) error {
+				exposed = append(exposed, key)
+				return nil
+			}
+		}
+	}
+	ctx := context.Background()
+
+	missing := Evaluate(ctx, provider, "checkout", checkoutV1, openfeature.NewTargetlessEvaluationContext(map[string]any{"plan": "pro"}))
+	if missing.Value != checkoutV1 || missing.ResolutionDetail().ErrorCode != openfeature.TargetingKeyMissingCode {
+		t.Errorf("Expected the validation hook to reject the context, got %+v", missing)
+	}
+	if version := Evaluate(ctx, provider, "checkout", checkoutV1, openfeature.NewEvaluationContext("user-1", nil)); version.Value != checkoutV2 {
+		t.Errorf("Expected checkout v2, got %+v", version)
+	}
+	if len(exposed) != 1 || exposed[0] != "user-1" {
+		t.Errorf("Expected one exposure for user-1, got %v", exposed)
+	}
+}
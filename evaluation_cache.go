@@ -0,0 +1,111 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+type evaluationCacheCtxKey struct{}
+
+// evaluationCache holds the Split results of the evaluations made with one context.Context.
+type evaluationCache struct {
+	mu      sync.Mutex
+	results map[evaluationCacheKey]splitResult
+}
+
+type evaluationCacheKey struct {
+	flag string
+	key  string
+	// attrs is the canonical encoding of the attributes (see canonicalAttributes). Keys compare it
+	// in full, so different attribute sets never share an entry.
+	attrs string
+}
+
+// splitResult is the outcome of one TreatmentWithConfig call.
+type splitResult struct {
+	treatment string
+	config    *string
+	// metadata is the shared, read-only FlagMetadata for config.
+	metadata openfeature.FlagMetadata
+	// cached reports that the result was served from an evaluation cache.
+	cached bool
+	// fallback reports that Split returned "control" and a fallback treatment replaced it.
+	fallback bool
+	// stale reports that the result was served from a snapshot (see WithSnapshot).
+	stale bool
+	// derivedKey is the source of the key the result was evaluated for when it was derived
+	// (see WithDerivedKeys).
+	derivedKey string
+}
+
+// WithEvaluationCache returns a copy of ctx carrying an empty evaluation cache. Evaluations made by a
+// SplitProvider with the returned context (or one derived from it) call Split once per flag, key and
+// attribute set; repeated evaluations reuse that result, report reason CACHED and generate no
+// further impressions. The cache is safe for concurrent use and lives as long as ctx, so it is meant
+// to be scoped to a single request.
+func WithEvaluationCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, evaluationCacheCtxKey{}, &evaluationCache{
+		results: make(map[evaluationCacheKey]splitResult),
+	})
+}
+
+func evaluationCacheFrom(ctx context.Context) *evaluationCache {
+	if ctx == nil {
+		return nil
+	}
+	cache, _ := ctx.Value(evaluationCacheCtxKey{}).(*evaluationCache)
+	return cache
+}
+
+func (c *evaluationCache) get(k evaluationCacheKey) (splitResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	result, ok := c.results[k]
+	return result, ok
+}
+
+func (c *evaluationCache) put(k evaluationCacheKey, result splitResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.results[k] = result
+}
+
+// canonicalAttributes encodes attrs independently of map iteration order, so that two attribute
+// sets encode alike only when they hold the same names with values of the same type and JSON form,
+// e.g. 1 and "1", or ["a b"] and ["a","b"], differ. Every name, type and value is length-prefixed,
+// so no value can mimic the separators of another.
+func canonicalAttributes(attrs map[string]interface{}) string {
+	if len(attrs) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		value := attrs[name]
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			encoded = []byte(fmt.Sprintf("%#v", value))
+		}
+		writeLengthPrefixed(&b, name)
+		writeLengthPrefixed(&b, fmt.Sprintf("%T", value))
+		writeLengthPrefixed(&b, string(encoded))
+	}
+	return b.String()
+}
+
+func writeLengthPrefixed(b *strings.Builder, s string) {
+	b.WriteString(strconv.Itoa(len(s)))
+	b.WriteByte(':')
+	b.WriteString(s)
+}
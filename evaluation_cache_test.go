@@ -0,0 +1,101 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+func TestEvaluationCache_ReusesResult(t *testing.T) {
+	var records []EvaluationRecord
+	provider := createRecordingProvider(t, NewEvaluationRecorder(func(r EvaluationRecord) {
+		records = append(records, r)
+	}))
+	ctx := WithEvaluationCache(context.Background())
+	flatCtx := openfeature.FlattenedContext{openfeature.TargetingKey: "key", "plan": "pro"}
+
+	first := provider.BooleanEvaluation(ctx, "my_feature", false, flatCtx)
+	second := provider.BooleanEvaluation(ctx, "my_feature", false, flatCtx)
+	// The same Split result is parsed again for the requested type.
+	asString := provider.StringEvaluation(ctx, "my_feature", "", flatCtx)
+
+	if first.Value != true || second.Value != true || asString.Value != "on" {
+		t.Fatalf("Unexpected values %v %v %q", first.Value, second.Value, asString.Value)
+	}
+	if first.Reason != openfeature.TargetingMatchReason {
+		t.Errorf("First evaluation reason should be TARGETING_MATCH, got %s", first.Reason)
+	}
+	if second.Reason != openfeature.CachedReason || asString.Reason != openfeature.CachedReason {
+		t.Errorf("Repeated evaluation reason should be CACHED, got %s and %s", second.Reason, asString.Reason)
+	}
+	if second.FlagMetadata[flagMetadataConfigKey] != first.FlagMetadata[flagMetadataConfigKey] {
+		t.Error("Cached result should keep the treatment config")
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 records, got %d", len(records))
+	}
+	if records[0].Impression == nil || records[1].Impression != nil || records[2].Impression != nil {
+		t.Error("Only the first evaluation should have generated an impression")
+	}
+}
+
+func TestEvaluationCache_DistinguishesKeysAndAttributes(t *testing.T) {
+	provider := createProvider(t)
+	ctx := WithEvaluationCache(context.Background())
+
+	on := provider.BooleanEvaluation(ctx, "my_feature", false, openfeature.FlattenedContext{openfeature.TargetingKey: "key"})
+	off := provider.BooleanEvaluation(ctx, "my_feature", true, openfeature.FlattenedContext{openfeature.TargetingKey: "randomKey"})
+	if on.Value != true || off.Value != false {
+		t.Errorf("Different keys must not share cache entries, got %v and %v", on.Value, off.Value)
+	}
+
+	withAttr := provider.BooleanEvaluation(ctx, "my_feature", false, openfeature.FlattenedContext{openfeature.TargetingKey: "key", "plan": "pro"})
+	if withAttr.Reason == openfeature.CachedReason {
+		t.Error("Different attributes must not share cache entries")
+	}
+}
+
+func TestEvaluationCache_NotUsedWithoutContext(t *testing.T) {
+	provider := createProvider(t)
+	flatCtx := openfeature.FlattenedContext{openfeature.TargetingKey: "key"}
+
+	provider.BooleanEvaluation(context.Background(), "my_feature", false, flatCtx)
+	result := provider.BooleanEvaluation(context.Background(), "my_feature", false, flatCtx)
+	if result.Reason != openfeature.TargetingMatchReason {
+		t.Errorf("Expected TARGETING_MATCH without a cache, got %s", result.Reason)
+	}
+}
+
+func TestCanonicalAttributes(t *testing.T) {
+	a := map[string]interface{}{"a": 1, "b": "x"}
+	b := map[string]interface{}{"b": "x", "a": 1}
+	if canonicalAttributes(a) != canonicalAttributes(b) {
+		t.Error("Encoding must not depend on map order")
+	}
+	for _, pair := range [][2]map[string]interface{}{
+		{{"a": 1}, {"a": "1"}},
+		{{"groups": []string{"beta testers"}}, {"groups": []string{"beta", "testers"}}},
+		{{"a": "1;\"b\"=int:2"}, {"a": "1", "b": 2}},
+		{{"a": "1", "b": "2"}, {"a": `1"1:b1:x`}},
+	} {
+		if canonicalAttributes(pair[0]) == canonicalAttributes(pair[1]) {
+			t.Errorf("Expected %v and %v to encode differently", pair[0], pair[1])
+		}
+	}
+}
+
+func TestEvaluationCache_DistinctAttributes(t *testing.T) {
+	provider, err := NewLocalhostProviderFromDefinitions([]LocalFlag{{Name: "checkout", Treatment: "on"}}, quietSDK())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+	ctx := WithEvaluationCache(context.Background())
+
+	provider.BooleanEvaluation(ctx, "checkout", false, openfeature.FlattenedContext{openfeature.TargetingKey: "key", "groups": []string{"beta testers"}})
+	result := provider.BooleanEvaluation(ctx, "checkout", false, openfeature.FlattenedContext{openfeature.TargetingKey: "key", "groups": []string{"beta", "testers"}})
+	if result.Reason == openfeature.CachedReason {
+		t.Error("Expected different attributes not to share a cached result")
+	}
+}
@@ -0,0 +1,82 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/splitio/go-split-commons/v9/dtos"
+)
+
+// EvaluationPropertiesAttribute is the reserved evaluation context attribute holding Split evaluation
+// properties, a map[string]any attached to the impressions of the evaluation. It is never passed to
+// Split as an attribute, to a ContextMapper or to context validation.
+const EvaluationPropertiesAttribute = "splitEvaluationProperties"
+
+type evaluationPropertiesCtxKey struct{}
+
+// WithEvaluationProperties returns a copy of ctx carrying Split evaluation properties, e.g. a request
+// ID or the name of the surface being rendered. Evaluations made with the returned context attach
+// them to their impressions. Properties already carried by ctx are kept unless overridden, and
+// properties in the EvaluationPropertiesAttribute attribute override both.
+//
+// Properties are normalized like tracking event properties (see NormalizeTrackingProperties);
+// unsupported ones are dropped. Results reused from an evaluation cache generate no impression and
+// so carry no properties.
+func WithEvaluationProperties(ctx context.Context, properties map[string]any) context.Context {
+	merged := make(map[string]any, len(properties))
+	for name, value := range evaluationPropertiesFrom(ctx) {
+		merged[name] = value
+	}
+	for name, value := range properties {
+		merged[name] = value
+	}
+	return context.WithValue(ctx, evaluationPropertiesCtxKey{}, merged)
+}
+
+func evaluationPropertiesFrom(ctx context.Context) map[string]any {
+	if ctx == nil {
+		return nil
+	}
+	properties, _ := ctx.Value(evaluationPropertiesCtxKey{}).(map[string]any)
+	return properties
+}
+
+// evaluationOptions returns the Split evaluation options for the properties in ctx and flatCtx, or
+// nil when there are none.
+func evaluationOptions(ctx context.Context, flatCtx openfeature.FlattenedContext) *dtos.EvaluationOptions {
+	fromCtx := evaluationPropertiesFrom(ctx)
+	fromAttr, _ := flatCtx[EvaluationPropertiesAttribute].(map[string]any)
+	if len(fromCtx) == 0 && len(fromAttr) == 0 {
+		return nil
+	}
+	merged := fromAttr
+	if len(fromCtx) > 0 {
+		merged = make(map[string]any, len(fromCtx)+len(fromAttr))
+		for name, value := range fromCtx {
+			merged[name] = value
+		}
+		for name, value := range fromAttr {
+			merged[name] = value
+		}
+	}
+	properties, _ := NormalizeTrackingProperties(merged)
+	if properties == nil {
+		return nil
+	}
+	return &dtos.EvaluationOptions{Properties: properties}
+}
+
+// withoutEvaluationProperties returns flatCtx without the EvaluationPropertiesAttribute attribute,
+// copying it only when the attribute is present.
+func withoutEvaluationProperties(flatCtx openfeature.FlattenedContext) openfeature.FlattenedContext {
+	if _, ok := flatCtx[EvaluationPropertiesAttribute]; !ok {
+		return flatCtx
+	}
+	stripped := make(openfeature.FlattenedContext, len(flatCtx)-1)
+	for name, value := range flatCtx {
+		if name != EvaluationPropertiesAttribute {
+			stripped[name] = value
+		}
+	}
+	return stripped
+}
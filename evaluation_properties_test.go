@@ -0,0 +1,88 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+func TestEvaluationOptions(t *testing.T) {
+	if options := evaluationOptions(context.Background(), openfeature.FlattenedContext{"plan": "pro"}); options != nil {
+		t.Errorf("Expected no options without properties, got %+v", options)
+	}
+
+	ctx := WithEvaluationProperties(context.Background(), map[string]any{"requestId": "r-1", "surface": "home"})
+	ctx = WithEvaluationProperties(ctx, map[string]any{"surface": "checkout"})
+	flatCtx := openfeature.FlattenedContext{
+		EvaluationPropertiesAttribute: map[string]any{"requestId": "r-2", "ab": map[string]any{"cohort": 3}, "tags": []string{"x"}},
+	}
+	options := evaluationOptions(ctx, flatCtx)
+	expected := map[string]interface{}{"requestId": "r-2", "surface": "checkout", "ab.cohort": 3}
+	if options == nil || !reflect.DeepEqual(options.Properties, expected) {
+		t.Errorf("Expected %v, got %+v", expected, options)
+	}
+}
+
+func TestWithEvaluationProperties_Impression(t *testing.T) {
+	var records []EvaluationRecord
+	recorder := NewEvaluationRecorder(func(r EvaluationRecord) { records = append(records, r) })
+	provider, err := NewLocalhostProviderFromDefinitions([]LocalFlag{{Name: "checkout", Treatment: "on"}},
+		quietSDK(), WithEvaluationRecorder(recorder))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+	ctx := WithEvaluationProperties(context.Background(), map[string]any{"requestId": "r-1"})
+	flatCtx := openfeature.FlattenedContext{
+		openfeature.TargetingKey:      "user",
+		EvaluationPropertiesAttribute: map[string]any{"surface": "cart"},
+	}
+
+	if result := provider.BooleanEvaluation(ctx, "checkout", false, flatCtx); result.Value != true {
+		t.Fatalf("Expected checkout on, got %+v", result)
+	}
+	if len(records) != 1 || records[0].Impression == nil {
+		t.Fatalf("Expected a record with an impression, got %+v", records)
+	}
+	var properties map[string]any
+	if err := json.Unmarshal([]byte(records[0].Impression.Properties), &properties); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(properties, map[string]any{"requestId": "r-1", "surface": "cart"}) {
+		t.Errorf("Unexpected impression properties %v", properties)
+	}
+}
+
+func TestEvaluationPropertiesAttribute_NotAnAttribute(t *testing.T) {
+	var mapped openfeature.FlattenedContext
+	mapper := ContextMapperFunc(func(flatCtx openfeature.FlattenedContext) (string, map[string]interface{}) {
+		mapped = flatCtx
+		return DefaultContextMapper().Map(flatCtx)
+	})
+	provider, err := NewLocalhostProviderFromDefinitions([]LocalFlag{{Name: "checkout", Treatment: "on"}},
+		quietSDK(), WithContextMapper(mapper))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+	flatCtx := openfeature.FlattenedContext{
+		openfeature.TargetingKey:      "user",
+		EvaluationPropertiesAttribute: map[string]any{"surface": "cart"},
+	}
+
+	provider.BooleanEvaluation(context.Background(), "checkout", false, flatCtx)
+	if _, ok := mapped[EvaluationPropertiesAttribute]; ok {
+		t.Error("Expected the properties attribute to be left out of the mapped context")
+	}
+	if _, ok := flatCtx[EvaluationPropertiesAttribute]; !ok {
+		t.Error("Expected the caller's context to be left untouched")
+	}
+
+	evalCtx := openfeature.NewEvaluationContext("user", map[string]any{EvaluationPropertiesAttribute: map[string]any{"surface": "cart"}})
+	if err := runValidationHook(t, DefaultContextLimits(), evalCtx); err != nil {
+		t.Errorf("Expected the properties attribute not to be validated, got %v", err)
+	}
+}
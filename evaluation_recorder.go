@@ -0,0 +1,184 @@
+package split_openfeature_provider_go
+
+import (
+	"sync"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	impressionlistener "github.com/splitio/go-client/v6/splitio/impressionListener"
+	"github.com/splitio/go-split-commons/v9/dtos"
+)
+
+// Impression is the Split impression generated by an evaluation.
+type Impression struct {
+	KeyName      string
+	BucketingKey string
+	Treatment    string
+	Label        string
+	ChangeNumber int64
+	Time         int64
+	// Properties holds the evaluation properties as JSON, empty when there are none
+	// (see WithEvaluationProperties).
+	Properties string
+}
+
+func newImpression(i dtos.Impression) *Impression {
+	return &Impression{
+		KeyName:      i.KeyName,
+		BucketingKey: i.BucketingKey,
+		Treatment:    i.Treatment,
+		Label:        i.Label,
+		ChangeNumber: i.ChangeNumber,
+		Time:         i.Time,
+		Properties:   i.Properties,
+	}
+}
+
+// EvaluationRecord describes one OpenFeature evaluation served by the provider together with the
+// Split impression it produced. Impression is nil when Split did not generate one, e.g. when the
+// targeting key was missing or the flag does not exist.
+type EvaluationRecord struct {
+	FlagKey      string
+	FlagType     openfeature.Type
+	TargetingKey string
+	DefaultValue any
+	Value        any
+	Variant      string
+	Reason       openfeature.Reason
+	ErrorCode    openfeature.ErrorCode
+	ErrorMessage string
+	Impression   *Impression
+}
+
+// EvaluationRecorder is a Split impression listener that correlates impressions with the
+// OpenFeature evaluations that produced them and delivers one EvaluationRecord per evaluation.
+//
+// Pass it to the provider with WithEvaluationRecorder. Constructors that create the Split factory
+// (NewProviderWithAPIKey, NewProviderSimple) register it as the SDK impression listener; when the
+// Split client is created by the caller, set it as conf.SplitSdkConfig.Advanced.ImpressionListener
+// before creating the factory, otherwise records are delivered without impressions.
+//
+// Impressions are matched by flag and key, so concurrent evaluations of the same flag for the same
+// key may receive each other's (equivalent) impression.
+type EvaluationRecorder struct {
+	deliver func(EvaluationRecord)
+
+	mu      sync.Mutex
+	pending map[pendingImpressionKey][]*evaluationTrace
+}
+
+type pendingImpressionKey struct {
+	flag string
+	key  string
+}
+
+// NewEvaluationRecorder returns a recorder that calls callback synchronously after each evaluation.
+// The callback runs on the evaluating goroutine and should return quickly.
+func NewEvaluationRecorder(callback func(EvaluationRecord)) *EvaluationRecorder {
+	return &EvaluationRecorder{
+		deliver: callback,
+		pending: make(map[pendingImpressionKey][]*evaluationTrace),
+	}
+}
+
+// NewChannelEvaluationRecorder returns a recorder that sends each record to ch without blocking.
+// Records are dropped when ch is full.
+func NewChannelEvaluationRecorder(ch chan<- EvaluationRecord) *EvaluationRecorder {
+	return NewEvaluationRecorder(func(record EvaluationRecord) {
+		select {
+		case ch <- record:
+		default:
+		}
+	})
+}
+
+// LogImpression implements impressionlistener.ImpressionListener. Impressions that do not belong to
+// an evaluation in progress in the provider are ignored.
+func (r *EvaluationRecorder) LogImpression(data impressionlistener.ILObject) {
+	pk := pendingImpressionKey{flag: data.Impression.FeatureName, key: data.Impression.KeyName}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, trace := range r.pending[pk] {
+		if trace.record.Impression == nil {
+			trace.record.Impression = newImpression(data.Impression)
+			return
+		}
+	}
+}
+
+// start begins tracing an evaluation. It returns nil when r is nil so callers need no extra checks.
+func (r *EvaluationRecorder) start(flagType openfeature.Type, flag string) *evaluationTrace {
+	if r == nil {
+		return nil
+	}
+	return &evaluationTrace{
+		recorder: r,
+		record: EvaluationRecord{
+			FlagKey:  flag,
+			FlagType: flagType,
+		},
+	}
+}
+
+// evaluationTrace accumulates the record of one evaluation while it is in progress.
+type evaluationTrace struct {
+	recorder *EvaluationRecorder
+	record   EvaluationRecord
+	pk       pendingImpressionKey
+}
+
+// setKey records the key the flag is evaluated for, whether Split is called or a cached result is
+// reused.
+func (t *evaluationTrace) setKey(key string) {
+	if t == nil {
+		return
+	}
+	t.record.TargetingKey = key
+}
+
+// expect registers the trace to receive the impression for key while Split evaluates the flag.
+func (t *evaluationTrace) expect(key string) {
+	if t == nil {
+		return
+	}
+	t.pk = pendingImpressionKey{flag: t.record.FlagKey, key: key}
+	r := t.recorder
+	r.mu.Lock()
+	r.pending[t.pk] = append(r.pending[t.pk], t)
+	r.mu.Unlock()
+}
+
+// done stops waiting for an impression.
+func (t *evaluationTrace) done() {
+	if t == nil {
+		return
+	}
+	r := t.recorder
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	traces := r.pending[t.pk]
+	for i, trace := range traces {
+		if trace == t {
+			traces = append(traces[:i], traces[i+1:]...)
+			break
+		}
+	}
+	if len(traces) == 0 {
+		delete(r.pending, t.pk)
+	} else {
+		r.pending[t.pk] = traces
+	}
+}
+
+// finish completes the record with the resolution and delivers it.
+func (t *evaluationTrace) finish(defaultValue any, value any, detail openfeature.ProviderResolutionDetail) {
+	resolution := detail.ResolutionDetail()
+	t.record.DefaultValue = defaultValue
+	t.record.Value = value
+	t.record.Variant = resolution.Variant
+	t.record.Reason = resolution.Reason
+	t.record.ErrorCode = resolution.ErrorCode
+	t.record.ErrorMessage = resolution.ErrorMessage
+	if t.recorder.deliver != nil {
+		t.recorder.deliver(t.record)
+	}
+}
@@ -0,0 +1,117 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/splitio/go-client/v6/splitio/client"
+	"github.com/splitio/go-client/v6/splitio/conf"
+	"github.com/splitio/go-toolkit/v5/logging"
+)
+
+// createRecordingProvider returns a provider over split.yaml whose impressions feed recorder.
+func createRecordingProvider(t *testing.T, recorder *EvaluationRecorder) *SplitProvider {
+	t.Helper()
+	cfg := conf.Default()
+	cfg.SplitFile = "./split.yaml"
+	cfg.LoggerConfig.LogLevel = logging.LevelNone
+	cfg.Advanced.ImpressionListener = recorder
+	factory, err := client.NewSplitFactory("localhost", cfg)
+	if err != nil {
+		t.Fatal("Error creating split factory")
+	}
+	splitClient := factory.Client()
+	if err := splitClient.BlockUntilReady(10); err != nil {
+		t.Fatal("Split SDK timeout error")
+	}
+	provider, err := NewProvider(splitClient, WithEvaluationRecorder(recorder))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return provider
+}
+
+func TestEvaluationRecorder_Success(t *testing.T) {
+	var records []EvaluationRecord
+	provider := createRecordingProvider(t, NewEvaluationRecorder(func(r EvaluationRecord) {
+		records = append(records, r)
+	}))
+	flatCtx := openfeature.FlattenedContext{openfeature.TargetingKey: "key"}
+
+	provider.IntEvaluation(context.Background(), "int_feature", 7, flatCtx)
+
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(records))
+	}
+	r := records[0]
+	if r.FlagKey != "int_feature" || r.FlagType != openfeature.Int || r.TargetingKey != "key" {
+		t.Errorf("Unexpected record identity %+v", r)
+	}
+	if r.DefaultValue != int64(7) || r.Value != int64(32) || r.Variant != "32" {
+		t.Errorf("Unexpected record values %+v", r)
+	}
+	if r.Reason != openfeature.TargetingMatchReason || r.ErrorCode != "" {
+		t.Errorf("Unexpected record resolution %+v", r)
+	}
+	if r.Impression == nil {
+		t.Fatal("Expected the Split impression to be attached")
+	}
+	if r.Impression.KeyName != "key" || r.Impression.Treatment != "32" {
+		t.Errorf("Unexpected impression %+v", *r.Impression)
+	}
+	if len(provider.recorder.pending) != 0 {
+		t.Errorf("Expected no pending traces, got %d", len(provider.recorder.pending))
+	}
+}
+
+func TestEvaluationRecorder_Errors(t *testing.T) {
+	ch := make(chan EvaluationRecord, 2)
+	provider := createRecordingProvider(t, NewChannelEvaluationRecorder(ch))
+
+	provider.BooleanEvaluation(context.Background(), "my_feature", true, openfeature.FlattenedContext{})
+	provider.BooleanEvaluation(context.Background(), "obj_feature", true, openfeature.FlattenedContext{openfeature.TargetingKey: "key"})
+	// The channel is full; this record is dropped instead of blocking.
+	provider.BooleanEvaluation(context.Background(), "obj_feature", true, openfeature.FlattenedContext{openfeature.TargetingKey: "key"})
+
+	missing := <-ch
+	if missing.ErrorCode != openfeature.TargetingKeyMissingCode || missing.Impression != nil || missing.Value != true {
+		t.Errorf("Unexpected record for missing key %+v", missing)
+	}
+	parseErr := <-ch
+	if parseErr.ErrorCode != openfeature.ParseErrorCode || parseErr.Impression == nil {
+		t.Errorf("Unexpected record for parse error %+v", parseErr)
+	}
+	if len(ch) != 0 {
+		t.Errorf("Expected the third record to be dropped, %d left", len(ch))
+	}
+}
+
+func TestEvaluationRecorder_CachedResults(t *testing.T) {
+	var records []EvaluationRecord
+	recorder := NewEvaluationRecorder(func(r EvaluationRecord) {
+		records = append(records, r)
+	})
+	provider, err := NewLocalhostProviderFromDefinitions([]LocalFlag{{Name: "checkout", Treatment: "on"}}, quietSDK(),
+		WithEvaluationRecorder(recorder), WithEvaluationDedup(time.Hour, 100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+	ctx := WithEvaluationCache(context.Background())
+	flatCtx := openfeature.FlattenedContext{openfeature.TargetingKey: "key"}
+
+	provider.BooleanEvaluation(ctx, "checkout", false, flatCtx)
+	provider.BooleanEvaluation(ctx, "checkout", false, flatCtx)
+	provider.BooleanEvaluation(context.Background(), "checkout", false, flatCtx)
+
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 records, got %d", len(records))
+	}
+	for _, r := range records[1:] {
+		if r.Reason != openfeature.CachedReason || r.TargetingKey != "key" || r.Impression != nil {
+			t.Errorf("Expected a cached record with its targeting key and no impression, got %+v", r)
+		}
+	}
+}
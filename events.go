@@ -0,0 +1,79 @@
+package split_openfeature_provider_go
+
+import (
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// eventBufferSize is the capacity of the provider event channel. Events are dropped rather than
+// blocking the provider when nobody consumes the channel.
+const eventBufferSize = 16
+
+// Init implements openfeature.StateHandler. The Split client is ready by the time the provider is
+// constructed, so there is nothing left to initialize.
+func (p *SplitProvider) Init(evaluationContext openfeature.EvaluationContext) error {
+	return nil
+}
+
+// Shutdown implements openfeature.StateHandler. It stops background work started by the provider
+// and destroys the Split factory if the provider created it; clients passed to NewProvider are
+// left for the caller to destroy.
+func (p *SplitProvider) Shutdown() {
+	p.mu.Lock()
+	watcher, snapshots := p.watcher, p.snapshots
+	p.watcher, p.snapshots = nil, nil
+	p.mu.Unlock()
+	if watcher != nil {
+		watcher.close()
+	}
+	if snapshots != nil {
+		snapshots.close()
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.factory != nil {
+		p.factory.Destroy()
+		p.factory = nil
+	}
+	if quiet := p.quiet.Swap(nil); quiet != nil {
+		quiet.close()
+	}
+}
+
+// state returns the state the provider's events last reported: STALE while it serves a snapshot
+// (see WithSnapshot), READY otherwise.
+func (p *SplitProvider) state() openfeature.State {
+	if p.stale.Load() != nil {
+		return openfeature.StaleState
+	}
+	return openfeature.ReadyState
+}
+
+// EventChannel implements openfeature.EventHandler.
+func (p *SplitProvider) EventChannel() <-chan openfeature.Event {
+	return p.events
+}
+
+// emit publishes an event without blocking. Configuration changes also discard the caches derived
+// from flag definitions (see invalidateCaches).
+func (p *SplitProvider) emit(eventType openfeature.EventType, details openfeature.ProviderEventDetails) {
+	if eventType == openfeature.ProviderConfigChange {
+		p.invalidateCaches()
+	}
+	select {
+	case p.events <- openfeature.Event{ProviderName: providerName, EventType: eventType, ProviderEventDetails: details}:
+	default:
+	}
+}
+
+// invalidateCaches discards the results reused by WithEvaluationDedup, the flag set membership of
+// disabled impressions, the parsed treatments and the allowed variants read from definitions. It runs
+// on configuration changes and whenever the provider swaps Split clients.
+func (p *SplitProvider) invalidateCaches() {
+	p.parsed.invalidate()
+	p.dedup.invalidate()
+	p.variants.invalidate()
+	if quiet := p.quiet.Load(); quiet != nil {
+		quiet.invalidate()
+	}
+}
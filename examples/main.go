@@ -16,8 +16,6 @@ import (
 	"os"
 
 	"github.com/open-feature/go-sdk/openfeature"
-	"github.com/splitio/go-client/v6/splitio/client"
-	"github.com/splitio/go-client/v6/splitio/conf"
 
 	splitProvider "github.com/splitio/split-openfeature-provider-go/v2"
 )
@@ -26,25 +24,15 @@ func main() {
 	ctx := context.Background()
 
 	// -------------------------------------------------------------------------
-	// Initialization: Split client (API key from env or localhost for demo)
+	// Initialization: Split provider (API key from env or localhost for demo)
 	// -------------------------------------------------------------------------
-	cfg := conf.Default()
-	apiKey := os.Getenv("SPLIT_API_KEY")
-	if apiKey == "" {
-		apiKey = "localhost"
-		cfg.SplitFile = "./split.yaml"
-	}
-
-	factory, err := client.NewSplitFactory(apiKey, cfg)
-	if err != nil {
-		log.Fatalf("create Split factory: %v", err)
-	}
-	splitClient := factory.Client()
-	if err := splitClient.BlockUntilReady(10); err != nil {
-		log.Fatalf("Split not ready: %v", err)
+	var provider *splitProvider.SplitProvider
+	var err error
+	if apiKey := os.Getenv("SPLIT_API_KEY"); apiKey != "" {
+		provider, err = splitProvider.NewProviderWithAPIKey(apiKey)
+	} else {
+		provider, err = splitProvider.NewLocalhostProvider("./split.yaml")
 	}
-
-	provider, err := splitProvider.NewProvider(splitClient)
 	if err != nil {
 		log.Fatalf("create provider: %v", err)
 	}
@@ -0,0 +1,161 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// DefaultExposureEventName is the event name exposure events are tracked with when
+// ExposureTracking.EventName is empty.
+const DefaultExposureEventName = "ff.exposure"
+
+// ExposureTracking configures the exposure events tracked by WithExposureTracking.
+type ExposureTracking struct {
+	// EventName is the Split event type. DefaultExposureEventName when empty.
+	EventName string
+	// TrafficType is the Split traffic type of the events. When empty it is read from the
+	// "trafficType" attribute of the evaluation context, like Track does, and evaluations without
+	// one are not tracked.
+	TrafficType string
+	// Flags lists the flags to track exposures for; all flags when empty.
+	Flags []string
+	// Window deduplicates exposures: an exposure of a flag to a key is tracked at most once per
+	// Window. Zero tracks every evaluation.
+	Window time.Duration
+}
+
+// WithExposureTracking adds a provider hook that tracks an exposure event to Split after every
+// successful evaluation of the configured flags, with properties "flag" and "variant" (the
+// treatment served). Fallback treatments (see WithFallbackTreatments) are not exposures and are not
+// tracked. Events that Split fails to track are reported to the WithTrackReporter reporter.
+func WithExposureTracking(config ExposureTracking) Option {
+	return func(o *providerOptions) {
+		config.Flags = append([]string(nil), config.Flags...)
+		o.exposures = &config
+	}
+}
+
+func (c *ExposureTracking) validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.EventName != "" {
+		if err := ValidateTrackingEventName(c.EventName); err != nil {
+			return err
+		}
+	}
+	if c.Window < 0 {
+		return errors.New("exposure deduplication window cannot be negative")
+	}
+	return nil
+}
+
+// exposureHook is the provider hook added by WithExposureTracking.
+type exposureHook struct {
+	openfeature.UnimplementedHook
+	provider    *SplitProvider
+	eventName   string
+	trafficType string
+	// flags is the set of tracked flags, nil to track all of them.
+	flags  map[string]struct{}
+	window time.Duration
+	now    func() time.Time
+	// track sends the event; it is the provider's Split client Track outside tests.
+	track func(key, trafficType, eventType string, value interface{}, properties map[string]interface{}) error
+
+	mu sync.Mutex
+	// seen holds when an exposure was last tracked per key and flag, while within the window.
+	seen      map[exposureKey]time.Time
+	lastSweep time.Time
+}
+
+type exposureKey struct {
+	key  string
+	flag string
+}
+
+func newExposureHook(p *SplitProvider, config ExposureTracking) *exposureHook {
+	h := &exposureHook{
+		provider:    p,
+		eventName:   config.EventName,
+		trafficType: config.TrafficType,
+		window:      config.Window,
+		now:         time.Now,
+		track: func(key, trafficType, eventType string, value interface{}, properties map[string]interface{}) error {
+			return p.currentClient().Track(key, trafficType, eventType, value, properties)
+		},
+		seen: make(map[exposureKey]time.Time),
+	}
+	if h.eventName == "" {
+		h.eventName = DefaultExposureEventName
+	}
+	if len(config.Flags) > 0 {
+		h.flags = make(map[string]struct{}, len(config.Flags))
+		for _, flag := range config.Flags {
+			h.flags[flag] = struct{}{}
+		}
+	}
+	return h
+}
+
+// After tracks the exposure of a successful evaluation. It never fails the evaluation.
+func (h *exposureHook) After(ctx context.Context, hookContext openfeature.HookContext, details openfeature.InterfaceEvaluationDetails, hookHints openfeature.HookHints) error {
+	if details.ErrorCode != "" || details.Reason == openfeature.DefaultReason || details.Variant == "" {
+		return nil
+	}
+	flag := hookContext.FlagKey()
+	if h.flags != nil {
+		if _, ok := h.flags[flag]; !ok {
+			return nil
+		}
+	}
+	evalCtx := hookContext.EvaluationContext()
+	in, _, ok := h.provider.mapContext(flattenEvaluationContext(evalCtx))
+	if !ok {
+		return nil
+	}
+	trafficType := h.trafficType
+	if trafficType == "" {
+		trafficType, _ = evalCtx.Attribute(trafficTypeAttribute).(string)
+		if trafficType == "" {
+			return nil
+		}
+	}
+	if !h.first(exposureKey{key: in.key, flag: flag}) {
+		return nil
+	}
+	properties := map[string]interface{}{"flag": flag, "variant": details.Variant}
+	if err := h.track(in.key, trafficType, h.eventName, nil, properties); err != nil && h.provider.reporter != nil {
+		h.provider.reporter(TrackReport{EventName: h.eventName, Err: fmt.Errorf("exposure of %q: %w", flag, err)})
+	}
+	return nil
+}
+
+// first records an exposure and reports whether it is the first one for k within the window.
+// Expired entries are swept at most once per window so the map only holds recent exposures.
+func (h *exposureHook) first(k exposureKey) bool {
+	if h.window == 0 {
+		return true
+	}
+	now := h.now()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if now.Sub(h.lastSweep) >= h.window {
+		for seenKey, at := range h.seen {
+			if now.Sub(at) >= h.window {
+				delete(h.seen, seenKey)
+			}
+		}
+		h.lastSweep = now
+	}
+	if at, ok := h.seen[k]; ok && now.Sub(at) < h.window {
+		return false
+	}
+	h.seen[k] = now
+	return true
+}
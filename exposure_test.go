@@ -0,0 +1,130 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+type trackedEvent struct {
+	key, trafficType, eventType string
+	properties                  map[string]interface{}
+}
+
+// exposureClient registers a localhost provider with exposure tracking under its own domain and
+// returns an OpenFeature client for it along with the events its exposure hook tracks.
+func exposureClient(t *testing.T, config ExposureTracking, opts ...Option) (*openfeature.Client, *exposureHook, func() []trackedEvent) {
+	t.Helper()
+	provider, err := NewLocalhostProviderFromDefinitions([]LocalFlag{
+		{Name: "checkout", Treatment: "on"},
+		{Name: "banner", Treatment: "blue"},
+	}, append([]Option{quietSDK(), WithExposureTracking(config)}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hook *exposureHook
+	for _, h := range provider.Hooks() {
+		if e, ok := h.(*exposureHook); ok {
+			hook = e
+		}
+	}
+	if hook == nil {
+		t.Fatal("Expected an exposure hook")
+	}
+	var mu sync.Mutex
+	var events []trackedEvent
+	hook.track = func(key, trafficType, eventType string, value interface{}, properties map[string]interface{}) error {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, trackedEvent{key, trafficType, eventType, properties})
+		return nil
+	}
+	if err := openfeature.SetNamedProviderAndWait(t.Name(), provider); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { provider.Shutdown() })
+	return openfeature.NewClient(t.Name()), hook, func() []trackedEvent {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]trackedEvent(nil), events...)
+	}
+}
+
+func TestExposureTracking(t *testing.T) {
+	client, _, events := exposureClient(t, ExposureTracking{TrafficType: "user", Flags: []string{"checkout"}})
+	ctx := context.Background()
+	evalCtx := openfeature.NewEvaluationContext("user-1", nil)
+
+	client.BooleanValue(ctx, "checkout", false, evalCtx)
+	client.StringValue(ctx, "banner", "", evalCtx)
+	client.BooleanValue(ctx, "missing", false, evalCtx)
+
+	expected := []trackedEvent{{
+		key:         "user-1",
+		trafficType: "user",
+		eventType:   DefaultExposureEventName,
+		properties:  map[string]interface{}{"flag": "checkout", "variant": "on"},
+	}}
+	if got := events(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestExposureTracking_TrafficTypeFromContext(t *testing.T) {
+	client, _, events := exposureClient(t, ExposureTracking{EventName: "experiment.exposure"})
+	ctx := context.Background()
+
+	client.BooleanValue(ctx, "checkout", false, openfeature.NewEvaluationContext("user-1", nil))
+	client.StringValue(ctx, "banner", "", openfeature.NewEvaluationContext("acct-1", map[string]any{"trafficType": "account"}))
+
+	got := events()
+	if len(got) != 1 || got[0].trafficType != "account" || got[0].eventType != "experiment.exposure" {
+		t.Errorf("Expected only the evaluation with a traffic type to be tracked, got %+v", got)
+	}
+}
+
+func TestExposureTracking_Window(t *testing.T) {
+	client, hook, events := exposureClient(t, ExposureTracking{TrafficType: "user", Window: time.Minute})
+	now := time.Now()
+	hook.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		client.BooleanValue(ctx, "checkout", false, openfeature.NewEvaluationContext("user-1", nil))
+	}
+	client.BooleanValue(ctx, "checkout", false, openfeature.NewEvaluationContext("user-2", nil))
+	if got := len(events()); got != 2 {
+		t.Errorf("Expected one exposure per key within the window, got %d", got)
+	}
+
+	now = now.Add(time.Minute)
+	client.BooleanValue(ctx, "checkout", false, openfeature.NewEvaluationContext("user-1", nil))
+	if got := len(events()); got != 3 {
+		t.Errorf("Expected the exposure to be tracked again after the window, got %d", got)
+	}
+	if len(hook.seen) != 1 {
+		t.Errorf("Expected expired exposures to be swept, got %v", hook.seen)
+	}
+}
+
+func TestExposureTracking_SkipsFallbacks(t *testing.T) {
+	client, _, events := exposureClient(t, ExposureTracking{TrafficType: "user"},
+		WithFallbackTreatments(FallbackTreatments{Global: &FallbackTreatment{Treatment: "off"}}))
+
+	client.BooleanValue(context.Background(), "missing", true, openfeature.NewEvaluationContext("user-1", nil))
+	if got := events(); len(got) != 0 {
+		t.Errorf("Expected fallback treatments not to be tracked, got %+v", got)
+	}
+}
+
+func TestWithExposureTracking_Invalid(t *testing.T) {
+	for _, config := range []ExposureTracking{{EventName: "bad name"}, {Window: -time.Second}} {
+		if _, err := newProviderOptions([]Option{WithExposureTracking(config)}); err == nil {
+			t.Errorf("Expected an error for %+v", config)
+		}
+	}
+}
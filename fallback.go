@@ -0,0 +1,76 @@
+package split_openfeature_provider_go
+
+import "fmt"
+
+// FallbackTreatment is a treatment, with optional config, served in place of "control".
+type FallbackTreatment struct {
+	Treatment string
+	Config    *string
+}
+
+// FallbackTreatments configures the treatments served when Split returns "control", e.g. because
+// the flag does not exist or the SDK cannot reach Split. A ByFlag entry takes precedence over Global.
+// Flags without an applicable fallback keep resolving to the caller's default value.
+type FallbackTreatments struct {
+	Global *FallbackTreatment
+	ByFlag map[string]FallbackTreatment
+}
+
+// WithFallbackTreatments makes the provider serve fallbacks instead of the caller's default value
+// when Split returns "control". Fallbacks resolve with reason DEFAULT, the fallback treatment as
+// variant and its config in FlagMetadata; a fallback that does not parse as the requested type
+// resolves to the caller's default value with a PARSE_ERROR, as any other treatment would.
+func WithFallbackTreatments(fallbacks FallbackTreatments) Option {
+	return func(o *providerOptions) {
+		o.fallbacks = fallbacks
+	}
+}
+
+func (f FallbackTreatments) validate() error {
+	if f.Global != nil {
+		if err := f.Global.validate(); err != nil {
+			return fmt.Errorf("global fallback: %w", err)
+		}
+	}
+	for flag, fallback := range f.ByFlag {
+		if err := fallback.validate(); err != nil {
+			return fmt.Errorf("fallback for flag %q: %w", flag, err)
+		}
+	}
+	return nil
+}
+
+func (f FallbackTreatment) validate() error {
+	if noTreatment(f.Treatment) {
+		return fmt.Errorf("treatment cannot be empty or %q", controlTreatment)
+	}
+	return nil
+}
+
+// resolve returns the fallback for flag, if any.
+func (f FallbackTreatments) resolve(flag string) (FallbackTreatment, bool) {
+	if fallback, ok := f.ByFlag[flag]; ok {
+		return fallback, true
+	}
+	if f.Global != nil {
+		return *f.Global, true
+	}
+	return FallbackTreatment{}, false
+}
+
+// withFallback replaces a "control" result with the configured fallback for flag, if any.
+func (p *SplitProvider) withFallback(flag string, result splitResult) splitResult {
+	if !noTreatment(result.treatment) {
+		return result
+	}
+	fallback, ok := p.fallbacks.resolve(flag)
+	if !ok {
+		return result
+	}
+	return splitResult{
+		treatment: fallback.Treatment,
+		config:    fallback.Config,
+		metadata:  p.metadata.forConfig(fallback.Config),
+		fallback:  true,
+	}
+}
@@ -0,0 +1,97 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+func createFallbackProvider(t *testing.T, fallbacks FallbackTreatments) *SplitProvider {
+	t.Helper()
+	provider, err := NewLocalhostProviderFromDefinitions([]LocalFlag{{Name: "existing", Treatment: "on"}},
+		quietSDK(), WithFallbackTreatments(fallbacks))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(provider.Shutdown)
+	return provider
+}
+
+func TestFallbackTreatments_ByFlagAndGlobal(t *testing.T) {
+	config := `{"color":"grey"}`
+	provider := createFallbackProvider(t, FallbackTreatments{
+		Global: &FallbackTreatment{Treatment: "off"},
+		ByFlag: map[string]FallbackTreatment{"checkout": {Treatment: "v1", Config: &config}},
+	})
+	ctx := context.Background()
+	flatCtx := openfeature.FlattenedContext{openfeature.TargetingKey: "key"}
+
+	result := provider.StringEvaluation(ctx, "checkout", "default", flatCtx)
+	if result.Value != "v1" || result.Variant != "v1" || result.Reason != openfeature.DefaultReason {
+		t.Errorf("Expected the checkout fallback with reason DEFAULT, got %+v", result)
+	}
+	if result.Error() != nil {
+		t.Errorf("Expected no resolution error, got %v", result.Error())
+	}
+	if result.FlagMetadata[flagMetadataConfigKey] != config {
+		t.Errorf("Expected the fallback config in metadata, got %v", result.FlagMetadata)
+	}
+
+	boolResult := provider.BooleanEvaluation(ctx, "missing", true, flatCtx)
+	if boolResult.Value != false || boolResult.Reason != openfeature.DefaultReason {
+		t.Errorf("Expected the global fallback, got %+v", boolResult)
+	}
+
+	if result := provider.BooleanEvaluation(ctx, "existing", false, flatCtx); result.Value != true || result.Reason != openfeature.TargetingMatchReason {
+		t.Errorf("Expected existing flags to be unaffected, got %+v", result)
+	}
+}
+
+func TestFallbackTreatments_ParseError(t *testing.T) {
+	provider := createFallbackProvider(t, FallbackTreatments{Global: &FallbackTreatment{Treatment: "off"}})
+	flatCtx := openfeature.FlattenedContext{openfeature.TargetingKey: "key"}
+
+	result := provider.IntEvaluation(context.Background(), "missing", 7, flatCtx)
+	if result.Value != 7 || result.Reason != openfeature.ErrorReason {
+		t.Errorf("Expected the default value with reason ERROR, got %+v", result)
+	}
+	if result.ResolutionDetail().ErrorCode != openfeature.ParseErrorCode {
+		t.Errorf("Expected %s, got %s", openfeature.ParseErrorCode, result.ResolutionDetail().ErrorCode)
+	}
+}
+
+func TestFallbackTreatments_NoFallback(t *testing.T) {
+	provider := createFallbackProvider(t, FallbackTreatments{ByFlag: map[string]FallbackTreatment{"checkout": {Treatment: "v1"}}})
+	flatCtx := openfeature.FlattenedContext{openfeature.TargetingKey: "key"}
+
+	result := provider.StringEvaluation(context.Background(), "missing", "default", flatCtx)
+	if result.Value != "default" || result.ResolutionDetail().ErrorCode != openfeature.FlagNotFoundCode {
+		t.Errorf("Expected the caller default with FLAG_NOT_FOUND, got %+v", result)
+	}
+}
+
+func TestFallbackTreatments_Cached(t *testing.T) {
+	provider := createFallbackProvider(t, FallbackTreatments{Global: &FallbackTreatment{Treatment: "off"}})
+	ctx := WithEvaluationCache(context.Background())
+	flatCtx := openfeature.FlattenedContext{openfeature.TargetingKey: "key"}
+
+	provider.BooleanEvaluation(ctx, "missing", true, flatCtx)
+	result := provider.BooleanEvaluation(ctx, "missing", true, flatCtx)
+	if result.Value != false || result.Reason != openfeature.DefaultReason {
+		t.Errorf("Expected the cached evaluation to serve the fallback with reason DEFAULT, got %+v", result)
+	}
+}
+
+func TestFallbackTreatments_Invalid(t *testing.T) {
+	invalid := []FallbackTreatments{
+		{Global: &FallbackTreatment{}},
+		{Global: &FallbackTreatment{Treatment: controlTreatment}},
+		{ByFlag: map[string]FallbackTreatment{"checkout": {}}},
+	}
+	for _, fallbacks := range invalid {
+		if _, err := newProviderOptions([]Option{WithFallbackTreatments(fallbacks)}); err == nil {
+			t.Errorf("Expected an error for %+v", fallbacks)
+		}
+	}
+}
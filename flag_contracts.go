@@ -0,0 +1,110 @@
+package split_openfeature_provider_go
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// FlagContract declares how the application evaluates a flag, so that ValidateFlags can check the
+// Split definition supports it.
+type FlagContract struct {
+	Name string
+	// Type is the OpenFeature type the flag is evaluated as. Every treatment of the flag must parse
+	// as this type with the same rules used by the *Evaluation methods.
+	Type openfeature.Type
+	// ValidateConfig, if set, is called with each treatment that has a config and that config. A
+	// non-nil error is reported as a problem of the flag.
+	ValidateConfig func(treatment string, config string) error
+}
+
+// FlagValidationResult is the outcome of checking one FlagContract.
+type FlagValidationResult struct {
+	Contract FlagContract
+	// Found reports whether the flag exists in the Split definitions.
+	Found bool
+	// Problems describes every incompatibility found; it is empty when the contract holds.
+	Problems []string
+}
+
+// OK reports whether the contract holds.
+func (r FlagValidationResult) OK() bool {
+	return r.Found && len(r.Problems) == 0
+}
+
+// FlagValidationReport holds one result per contract, in the order the contracts were given.
+type FlagValidationReport struct {
+	Results []FlagValidationResult
+}
+
+// OK reports whether every contract holds.
+func (r FlagValidationReport) OK() bool {
+	for _, result := range r.Results {
+		if !result.OK() {
+			return false
+		}
+	}
+	return true
+}
+
+// Err returns an error listing every failed contract, or nil if all of them hold.
+func (r FlagValidationReport) Err() error {
+	var failures []string
+	for _, result := range r.Results {
+		if !result.OK() {
+			failures = append(failures, fmt.Sprintf("%s (%s): %s", result.Contract.Name, result.Contract.Type, strings.Join(result.Problems, "; ")))
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	return errors.New("flag contracts not satisfied: " + strings.Join(failures, ", "))
+}
+
+// ValidateFlags checks each contract against the flag definitions known to the Split manager: the
+// flag must exist and every treatment it can serve must parse as the declared type. It returns an
+// error only when the definitions cannot be read (see ErrNoSplitManager); failed contracts are
+// reported in the FlagValidationReport. Typically called at startup:
+//
+//	report, err := provider.ValidateFlags(contracts)
+//	if err == nil {
+//		err = report.Err()
+//	}
+func (p *SplitProvider) ValidateFlags(contracts []FlagContract) (FlagValidationReport, error) {
+	if p.currentManager() == nil {
+		return FlagValidationReport{}, ErrNoSplitManager
+	}
+	report := FlagValidationReport{Results: make([]FlagValidationResult, 0, len(contracts))}
+	for _, contract := range contracts {
+		report.Results = append(report.Results, p.validateFlag(contract))
+	}
+	return report, nil
+}
+
+func (p *SplitProvider) validateFlag(contract FlagContract) FlagValidationResult {
+	result := FlagValidationResult{Contract: contract}
+	definition, err := p.Flag(contract.Name)
+	if err != nil {
+		result.Problems = append(result.Problems, err.Error())
+		return result
+	}
+	result.Found = true
+	if len(definition.Treatments) == 0 {
+		result.Problems = append(result.Problems, "flag has no treatments")
+	}
+	for _, treatment := range definition.Treatments {
+		if err := parseTreatment(contract.Type, treatment); err != nil {
+			result.Problems = append(result.Problems, fmt.Sprintf("treatment %q is not a valid %s: %v", treatment, contract.Type, err))
+		}
+		config, ok := definition.Config(treatment)
+		if ok && contract.ValidateConfig != nil {
+			if err := contract.ValidateConfig(treatment, config); err != nil {
+				result.Problems = append(result.Problems, fmt.Sprintf("config of treatment %q is invalid: %v", treatment, err))
+			}
+		}
+	}
+	result.Problems = append(result.Problems, p.schemaProblems(definition)...)
+	return result
+}
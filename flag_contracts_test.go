@@ -0,0 +1,86 @@
+package split_openfeature_provider_go
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+func TestValidateFlags(t *testing.T) {
+	provider, err := NewLocalhostProvider("./split.yaml", quietSDK())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+
+	report, err := provider.ValidateFlags([]FlagContract{
+		{Name: "my_feature", Type: openfeature.Boolean},
+		{Name: "int_feature", Type: openfeature.Int},
+		{Name: "int_feature", Type: openfeature.Float},
+		{Name: "float_feature", Type: openfeature.Float},
+		{Name: "obj_feature", Type: openfeature.Object},
+		{Name: "some_other_feature", Type: openfeature.String},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.OK() || report.Err() != nil {
+		t.Errorf("Expected all contracts to hold, got %v", report.Err())
+	}
+}
+
+func TestValidateFlags_Failures(t *testing.T) {
+	provider, err := NewLocalhostProvider("./split.yaml", quietSDK())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+
+	report, err := provider.ValidateFlags([]FlagContract{
+		{Name: "renamed_feature", Type: openfeature.Int},
+		{Name: "float_feature", Type: openfeature.Int},
+		{Name: "obj_feature", Type: openfeature.Boolean},
+		{Name: "my_feature", Type: openfeature.Boolean, ValidateConfig: func(treatment, config string) error {
+			var v map[string]any
+			if err := json.Unmarshal([]byte(config), &v); err != nil {
+				return err
+			}
+			if _, ok := v["color"]; !ok {
+				return errors.New("missing color")
+			}
+			return nil
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.OK() {
+		t.Fatal("Expected failed contracts")
+	}
+	if len(report.Results) != 4 {
+		t.Fatalf("Expected 4 results, got %d", len(report.Results))
+	}
+	missing := report.Results[0]
+	if missing.Found || len(missing.Problems) == 0 {
+		t.Errorf("Expected renamed_feature to be reported missing, got %+v", missing)
+	}
+	for i, want := range []string{`treatment "32.5" is not a valid int`, `treatment "{\"key\": \"value\"}" is not a valid bool`, `config of treatment "on" is invalid: missing color`} {
+		result := report.Results[i+1]
+		if !result.Found || len(result.Problems) != 1 || !strings.Contains(result.Problems[0], want) {
+			t.Errorf("Expected problem containing %q, got %+v", want, result.Problems)
+		}
+	}
+	if err := report.Err(); err == nil || !strings.Contains(err.Error(), "renamed_feature") {
+		t.Errorf("Expected combined error naming renamed_feature, got %v", err)
+	}
+}
+
+func TestValidateFlags_NoManager(t *testing.T) {
+	provider := createProvider(t)
+	if _, err := provider.ValidateFlags([]FlagContract{{Name: "my_feature", Type: openfeature.Boolean}}); !errors.Is(err, ErrNoSplitManager) {
+		t.Errorf("Expected ErrNoSplitManager, got %v", err)
+	}
+}
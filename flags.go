@@ -0,0 +1,115 @@
+package split_openfeature_provider_go
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/splitio/go-client/v6/splitio/client"
+)
+
+// controlTreatment is the treatment Split returns when it cannot evaluate a flag.
+const controlTreatment = "control"
+
+// FlagDefinition is the provider-level view of a Split feature flag definition.
+type FlagDefinition struct {
+	Name        string
+	TrafficType string
+	// Treatments lists the distinct treatments the flag can serve: those of its targeting rules plus
+	// the default treatment, in definition order. "control" is never included.
+	Treatments []string
+	// Configs maps treatments to their configs. Treatments without config are absent.
+	Configs          map[string]string
+	DefaultTreatment string
+	Killed           bool
+	ChangeNumber     int64
+	Sets             []string
+}
+
+// Config returns the config of treatment and whether it has one.
+func (d FlagDefinition) Config(treatment string) (string, bool) {
+	config, ok := d.Configs[treatment]
+	return config, ok
+}
+
+// Flags returns the definitions of all flags known to the Split SDK, sorted by name.
+func (p *SplitProvider) Flags() ([]FlagDefinition, error) {
+	manager := p.currentManager()
+	if manager == nil {
+		return nil, ErrNoSplitManager
+	}
+	views := manager.Splits()
+	definitions := make([]FlagDefinition, 0, len(views))
+	for i := range views {
+		definitions = append(definitions, newFlagDefinition(&views[i]))
+	}
+	sort.Slice(definitions, func(i, j int) bool { return definitions[i].Name < definitions[j].Name })
+	return definitions, nil
+}
+
+// Flag returns the definition of the named flag, or an error wrapping ErrFlagNotFound.
+func (p *SplitProvider) Flag(name string) (FlagDefinition, error) {
+	manager := p.currentManager()
+	if manager == nil {
+		return FlagDefinition{}, ErrNoSplitManager
+	}
+	view := manager.Split(name)
+	if view == nil {
+		return FlagDefinition{}, fmt.Errorf("%w: %s", ErrFlagNotFound, name)
+	}
+	return newFlagDefinition(view), nil
+}
+
+// currentManager returns the Split manager matching the client currently serving evaluations.
+func (p *SplitProvider) currentManager() *client.SplitManager {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.factory != nil {
+		return p.factory.Manager()
+	}
+	return p.manager
+}
+
+// changeNumber returns the change number of flag's definition, or -1 when the flag or the Split
+// manager is missing.
+func (p *SplitProvider) changeNumber(flag string) int64 {
+	manager := p.currentManager()
+	if manager == nil {
+		return -1
+	}
+	view := manager.Split(flag)
+	if view == nil {
+		return -1
+	}
+	return view.ChangeNumber
+}
+
+func newFlagDefinition(view *client.SplitView) FlagDefinition {
+	definition := FlagDefinition{
+		Name:             view.Name,
+		TrafficType:      view.TrafficType,
+		DefaultTreatment: view.DefaultTreatment,
+		Killed:           view.Killed,
+		ChangeNumber:     view.ChangeNumber,
+		Sets:             append([]string(nil), view.Sets...),
+	}
+	seen := make(map[string]bool)
+	addTreatment := func(treatment string) {
+		// Localhost definitions carry a zero-size "_" partition that is never served.
+		if treatment == "" || treatment == "_" || treatment == controlTreatment || seen[treatment] {
+			return
+		}
+		seen[treatment] = true
+		definition.Treatments = append(definition.Treatments, treatment)
+	}
+	for _, treatment := range view.Treatments {
+		addTreatment(treatment)
+	}
+	addTreatment(view.DefaultTreatment)
+	if len(view.Configs) > 0 {
+		definition.Configs = make(map[string]string, len(view.Configs))
+		for treatment, config := range view.Configs {
+			definition.Configs[treatment] = config
+		}
+	}
+	return definition
+}
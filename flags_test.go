@@ -0,0 +1,75 @@
+package split_openfeature_provider_go
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFlags(t *testing.T) {
+	provider, err := NewLocalhostProvider("./split.yaml", quietSDK())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+
+	flags, err := provider.Flags()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, flag := range flags {
+		names = append(names, flag.Name)
+	}
+	expected := []string{"float_feature", "int_feature", "my_feature", "obj_feature", "some_other_feature"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected flags %v, got %v", expected, names)
+	}
+}
+
+func TestFlag(t *testing.T) {
+	provider, err := NewLocalhostProvider("./split.yaml", quietSDK())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+
+	flag, err := provider.Flag("my_feature")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(flag.Treatments, []string{"on", "off"}) {
+		t.Errorf("Expected treatments [on off], got %v", flag.Treatments)
+	}
+	config, ok := flag.Config("on")
+	if !ok || config != "{\"desc\" : \"this applies only to ON treatment\"}" {
+		t.Errorf("Unexpected config for on: %q", config)
+	}
+	if _, ok := flag.Config("off"); ok {
+		t.Error("Expected no config for off")
+	}
+
+	if _, err := provider.Flag("random-non-existent-feature"); !errors.Is(err, ErrFlagNotFound) {
+		t.Errorf("Expected ErrFlagNotFound, got %v", err)
+	}
+}
+
+func TestFlags_WithManager(t *testing.T) {
+	provider := createProvider(t)
+	if _, err := provider.Flags(); !errors.Is(err, ErrNoSplitManager) {
+		t.Errorf("Expected ErrNoSplitManager without a manager, got %v", err)
+	}
+
+	localhost, err := NewLocalhostProvider("./split.yaml", quietSDK())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer localhost.Shutdown()
+	withManager, err := NewProvider(localhost.currentClient(), WithManager(localhost.currentManager()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := withManager.Flag("int_feature"); err != nil {
+		t.Errorf("Unexpected error with WithManager: %v", err)
+	}
+}
@@ -3,9 +3,13 @@ module github.com/splitio/split-openfeature-provider-go/v2
 go 1.24.0
 
 require (
+	github.com/alicebob/miniredis/v2 v2.37.0
 	github.com/open-feature/go-sdk v1.17.1
+	github.com/santhosh-tekuri/jsonschema/v6 v6.0.2
 	github.com/splitio/go-client/v6 v6.10.0
+	github.com/splitio/go-split-commons/v9 v9.1.0
 	github.com/splitio/go-toolkit/v5 v5.4.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -17,11 +21,11 @@ require (
 	github.com/go-logr/logr v1.4.3 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/redis/go-redis/v9 v9.0.4 // indirect
-	github.com/splitio/go-split-commons/v9 v9.1.0 // indirect
 	github.com/stretchr/objx v0.5.2 // indirect
 	github.com/stretchr/testify v1.11.1 // indirect
+	github.com/yuin/gopher-lua v1.1.1 // indirect
 	go.uber.org/mock v0.6.0 // indirect
 	golang.org/x/exp v0.0.0-20231006140011-7918f672742d // indirect
 	golang.org/x/sync v0.18.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
+	golang.org/x/text v0.31.0 // indirect
 )
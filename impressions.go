@@ -0,0 +1,211 @@
+package split_openfeature_provider_go
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/splitio/go-client/v6/splitio/client"
+	"github.com/splitio/go-client/v6/splitio/conf"
+	commonsconf "github.com/splitio/go-split-commons/v9/conf"
+	"github.com/splitio/go-split-commons/v9/flagsets"
+)
+
+// ImpressionsMode is the Split SDK impressions mode set with WithImpressionsMode.
+type ImpressionsMode string
+
+const (
+	// ImpressionsModeOptimized sends each impression once per hour and key and counts the rest.
+	// It is the Split SDK default.
+	ImpressionsModeOptimized ImpressionsMode = commonsconf.ImpressionsModeOptimized
+	// ImpressionsModeDebug sends every impression.
+	ImpressionsModeDebug ImpressionsMode = commonsconf.ImpressionsModeDebug
+	// ImpressionsModeNone sends no impressions, only impression counts and unique keys.
+	ImpressionsModeNone ImpressionsMode = commonsconf.ImpressionsModeNone
+)
+
+// impressionSetsRefresh is how often the flags in the sets given to WithImpressionsDisabledFlagSets
+// are looked up again.
+const impressionSetsRefresh = 30 * time.Second
+
+var errImpressionsNeedFactory = errors.New("impressions options only apply to constructors that create the Split client")
+
+// impressionSettings holds the settings given with the impressions options.
+type impressionSettings struct {
+	mode  ImpressionsMode
+	flags []string
+	sets  []string
+}
+
+// WithImpressionsMode sets the Split SDK impressions mode. Like WithSDKConfig it only applies to
+// constructors that create the Split client; NewProvider rejects it.
+func WithImpressionsMode(mode ImpressionsMode) Option {
+	return func(o *providerOptions) {
+		o.impressions.mode = mode
+	}
+}
+
+// WithImpressionsDisabled stops the given flags from generating impressions. It runs a second Split
+// factory; see WithImpressionsDisabledFlagSets for how and at what cost.
+func WithImpressionsDisabled(flags ...string) Option {
+	return func(o *providerOptions) {
+		o.impressions.flags = append(o.impressions.flags, flags...)
+	}
+}
+
+// WithImpressionsDisabledFlagSets stops the flags in the given flag sets from generating impressions.
+//
+// The Split SDK has no per-flag impressions setting on the client side, so the provider creates a
+// second, complete Split factory in ImpressionsModeNone and evaluates those flags with it. That
+// factory synchronizes with Split on its own, with its own polling or streaming connection and its
+// own in-memory copy of the flag definitions, so it doubles the provider's network traffic and
+// definition memory (Redis consumer mode only adds a Redis connection pool). Constructors wait for
+// it up to the ready timeout (see WithReadyTimeout) but do not fail if it is not ready; until it is,
+// and after Shutdown, the flags are evaluated by the main client and generate impressions as usual.
+// Flag set membership is looked up every 30 seconds and on PROVIDER_CONFIGURATION_CHANGED.
+// Localhost mode never sends impressions, so there the option has no effect. Like
+// WithImpressionsMode it is rejected by NewProvider.
+func WithImpressionsDisabledFlagSets(sets ...string) Option {
+	return func(o *providerOptions) {
+		o.impressions.sets = append(o.impressions.sets, sets...)
+	}
+}
+
+// set reports whether any impressions option was given.
+func (s impressionSettings) set() bool {
+	return s.mode != "" || len(s.flags) > 0 || len(s.sets) > 0
+}
+
+// disablesFlags reports whether impressions are disabled for some flags.
+func (s impressionSettings) disablesFlags() bool {
+	return len(s.flags) > 0 || len(s.sets) > 0
+}
+
+func (s impressionSettings) validate() error {
+	switch s.mode {
+	case "", ImpressionsModeOptimized, ImpressionsModeDebug, ImpressionsModeNone:
+	default:
+		return fmt.Errorf("unknown impressions mode %q", s.mode)
+	}
+	for _, flag := range s.flags {
+		if flag == "" {
+			return errors.New("flag names with impressions disabled cannot be empty")
+		}
+	}
+	for _, set := range s.sets {
+		if _, errs := flagsets.Sanitize(set); len(errs) > 0 {
+			return fmt.Errorf("flag set %q: %w", set, errs[0])
+		}
+	}
+	return nil
+}
+
+func (s impressionSettings) apply(cfg *conf.SplitSdkConfig) {
+	if s.mode != "" {
+		cfg.ImpressionsMode = string(s.mode)
+	}
+}
+
+// quietClient evaluates the flags whose impressions are disabled with a second Split factory in
+// ImpressionsModeNone.
+type quietClient struct {
+	factory *client.SplitFactory
+	// manager looks up flag set membership, from the provider's main factory.
+	manager *client.SplitManager
+	flags   map[string]struct{}
+	sets    map[string]struct{}
+	now     func() time.Time
+
+	// membership holds the flags of the configured sets, nil until looked up or after invalidate.
+	membership atomic.Pointer[setMembership]
+	// mu serializes lookups.
+	mu sync.Mutex
+}
+
+type setMembership struct {
+	flags map[string]struct{}
+	at    time.Time
+}
+
+// newQuietClient creates the factory for the flags disabled in settings, with a copy of cfg, and
+// waits up to readyTimeout seconds for it. A factory that is not ready by then is kept: clientFor
+// starts returning it once it is.
+func newQuietClient(apiKey string, cfg *conf.SplitSdkConfig, manager *client.SplitManager, settings impressionSettings, readyTimeout int) (*quietClient, error) {
+	quietCfg := *cfg
+	quietCfg.ImpressionsMode = commonsconf.ImpressionsModeNone
+	factory, err := client.NewSplitFactory(apiKey, &quietCfg)
+	if err != nil {
+		return nil, err
+	}
+	_ = factory.BlockUntilReady(readyTimeout)
+	q := &quietClient{
+		factory: factory,
+		manager: manager,
+		flags:   make(map[string]struct{}, len(settings.flags)),
+		sets:    make(map[string]struct{}, len(settings.sets)),
+		now:     time.Now,
+	}
+	for _, flag := range settings.flags {
+		q.flags[flag] = struct{}{}
+	}
+	for _, set := range settings.sets {
+		q.sets[set] = struct{}{}
+	}
+	return q, nil
+}
+
+// clientFor returns the quiet Split client if flag has impressions disabled and the client is
+// ready, or nil.
+func (q *quietClient) clientFor(flag string) *client.SplitClient {
+	if !q.covers(flag) || !q.factory.IsReady() {
+		return nil
+	}
+	return q.factory.Client()
+}
+
+func (q *quietClient) covers(flag string) bool {
+	if _, ok := q.flags[flag]; ok {
+		return true
+	}
+	if len(q.sets) == 0 {
+		return false
+	}
+	m := q.membership.Load()
+	if m == nil || q.now().Sub(m.at) >= impressionSetsRefresh {
+		m = q.lookup()
+	}
+	_, ok := m.flags[flag]
+	return ok
+}
+
+// lookup reads the flags in the configured sets from the manager, unless another evaluation just did.
+func (q *quietClient) lookup() *setMembership {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	now := q.now()
+	if m := q.membership.Load(); m != nil && now.Sub(m.at) < impressionSetsRefresh {
+		return m
+	}
+	m := &setMembership{flags: make(map[string]struct{}), at: now}
+	for _, view := range q.manager.Splits() {
+		for _, set := range view.Sets {
+			if _, ok := q.sets[set]; ok {
+				m.flags[view.Name] = struct{}{}
+				break
+			}
+		}
+	}
+	q.membership.Store(m)
+	return m
+}
+
+// invalidate makes the next evaluation look up flag set membership again.
+func (q *quietClient) invalidate() {
+	q.membership.Store(nil)
+}
+
+func (q *quietClient) close() {
+	q.factory.Destroy()
+}
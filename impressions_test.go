@@ -0,0 +1,144 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+const redisBannerFlag = `{"name": "banner", "trafficTypeName": "user", "status": "ACTIVE", "killed": false,
+ "defaultTreatment": "blue", "changeNumber": 1, "algo": 2, "seed": 1, "trafficAllocation": 100,
+ "trafficAllocationSeed": 1, "conditions": [], "sets": ["quiet_flags"]}`
+
+// redisImpressions returns the flags of the impressions the provider wrote to Redis.
+func redisImpressions(t *testing.T, server *miniredis.Miniredis, prefix string) []string {
+	t.Helper()
+	if !server.Exists(prefix + ".SPLITIO.impressions") {
+		return nil
+	}
+	entries, err := server.List(prefix + ".SPLITIO.impressions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var flags []string
+	for _, entry := range entries {
+		for _, flag := range []string{"checkout", "banner"} {
+			if strings.Contains(entry, `"f":"`+flag+`"`) {
+				flags = append(flags, flag)
+			}
+		}
+	}
+	return flags
+}
+
+func TestWithImpressionsDisabled(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  Option
+	}{
+		{name: "flags", opt: WithImpressionsDisabled("banner")},
+		{name: "flag sets", opt: WithImpressionsDisabledFlagSets("quiet_flags")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := miniredis.RunT(t)
+			synchronize(t, server, "myapp")
+			if err := server.Set("myapp.SPLITIO.split.banner", redisBannerFlag); err != nil {
+				t.Fatal(err)
+			}
+			provider, err := NewRedisConsumerProvider(context.Background(), "api-key", redisConfigFor(t, server, "myapp"),
+				quietSDK(), WithImpressionsMode(ImpressionsModeDebug), test.opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer provider.Shutdown()
+			ctx := context.Background()
+			user := openfeature.FlattenedContext{openfeature.TargetingKey: "user"}
+
+			if result := provider.StringEvaluation(ctx, "banner", "", user); result.Value != "blue" {
+				t.Errorf("Expected the quiet client to evaluate banner, got %+v", result)
+			}
+			provider.StringEvaluation(ctx, "checkout", "", user)
+
+			if flags := redisImpressions(t, server, "myapp"); len(flags) != 1 || flags[0] != "checkout" {
+				t.Errorf("Expected an impression for checkout only, got %v", flags)
+			}
+		})
+	}
+}
+
+func TestQuietClient_FlagSetMembership(t *testing.T) {
+	server := miniredis.RunT(t)
+	synchronize(t, server, "myapp")
+	provider, err := NewRedisConsumerProvider(context.Background(), "api-key", redisConfigFor(t, server, "myapp"),
+		quietSDK(), WithImpressionsDisabledFlagSets("quiet_flags"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+
+	if provider.quiet.Load().covers("banner") {
+		t.Fatal("Expected banner not to be covered before it exists")
+	}
+	if err := server.Set("myapp.SPLITIO.split.banner", redisBannerFlag); err != nil {
+		t.Fatal(err)
+	}
+	if provider.quiet.Load().covers("banner") {
+		t.Error("Expected membership to be reused until the refresh interval")
+	}
+	provider.emit(openfeature.ProviderConfigChange, openfeature.ProviderEventDetails{})
+	if !provider.quiet.Load().covers("banner") || provider.quiet.Load().covers("checkout") {
+		t.Error("Expected membership to be looked up again after a configuration change")
+	}
+}
+
+func TestQuietClient_NotReady(t *testing.T) {
+	server := miniredis.RunT(t)
+	synchronize(t, server, "myapp")
+	if err := server.Set("myapp.SPLITIO.split.banner", redisBannerFlag); err != nil {
+		t.Fatal(err)
+	}
+	provider, err := NewRedisConsumerProvider(context.Background(), "api-key", redisConfigFor(t, server, "myapp"),
+		quietSDK(), WithImpressionsDisabled("banner"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	quiet := provider.quiet.Load()
+	quiet.factory.Destroy()
+	if quiet.clientFor("banner") != nil {
+		t.Error("Expected a quiet factory that is not ready not to be used")
+	}
+	user := openfeature.FlattenedContext{openfeature.TargetingKey: "user"}
+	if result := provider.StringEvaluation(context.Background(), "banner", "", user); result.Value != "blue" {
+		t.Errorf("Expected the main client to evaluate banner meanwhile, got %+v", result)
+	}
+
+	provider.Shutdown()
+	if provider.quiet.Load() != nil {
+		t.Error("Expected Shutdown to clear the quiet client")
+	}
+}
+
+func TestImpressionsOptions_NewProvider(t *testing.T) {
+	provider := createProvider(t)
+	_, err := NewProvider(provider.currentClient(), WithImpressionsDisabled("checkout"))
+	if !errors.Is(err, errImpressionsNeedFactory) {
+		t.Errorf("Expected NewProvider to reject impressions options, got %v", err)
+	}
+}
+
+func TestImpressionsOptions_Invalid(t *testing.T) {
+	for _, opt := range []Option{
+		WithImpressionsMode("verbose"),
+		WithImpressionsDisabled(""),
+		WithImpressionsDisabledFlagSets("Not A Set"),
+	} {
+		if _, err := newProviderOptions([]Option{opt}); err == nil {
+			t.Error("Expected an error")
+		}
+	}
+}
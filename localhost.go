@@ -0,0 +1,109 @@
+package split_openfeature_provider_go
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/splitio/go-client/v6/splitio/conf"
+)
+
+// localhostAPIKey is the API key that puts the Split SDK in localhost mode.
+const localhostAPIKey = "localhost"
+
+// LocalFlag defines one treatment of a feature flag for Split localhost mode. Several LocalFlag
+// values with the same Name make up one flag: entries with Keys serve their treatment to those keys
+// only, and the last entry without Keys is served to every other key.
+type LocalFlag struct {
+	Name      string
+	Treatment string
+	// Keys restricts the treatment to these targeting keys. Empty means all keys.
+	Keys []string
+	// Config is the optional treatment config, usually a JSON string.
+	Config string
+}
+
+// NewLocalhostProvider creates a SplitProvider in Split localhost mode that reads flag definitions
+// from path. As in the Split SDK, the format follows the file extension: .yaml or .yml for YAML
+// definitions, .json for Split JSON definitions and anything else (e.g. .split) for the legacy
+// "<flag> <treatment>" line format. It blocks until the definitions are loaded (up to 10 seconds).
+// Use WithLocalhostFileWatch to pick up changes to the file while the provider runs.
+func NewLocalhostProvider(path string, opts ...Option) (*SplitProvider, error) {
+	return newLocalhostProvider(path, opts, true)
+}
+
+func newLocalhostProvider(path string, opts []Option, watch bool) (*SplitProvider, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("localhost split file: %w", err)
+	}
+	o, err := newProviderOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	cfg := conf.Default()
+	cfg.SplitFile = path
+	p, err := newProviderWithConfig(localhostAPIKey, cfg, opts)
+	if err != nil {
+		return nil, err
+	}
+	if watch && o.fileWatch > 0 {
+		if err := p.watchLocalhostFile(path, cfg, o.fileWatch); err != nil {
+			p.Shutdown()
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// NewLocalhostProviderFromDefinitions creates a SplitProvider in Split localhost mode serving the
+// given in-memory flag definitions. The definitions are written to a temporary YAML file that is
+// removed once the SDK has loaded it, so localhost refresh and WithLocalhostFileWatch do not apply.
+func NewLocalhostProviderFromDefinitions(flags []LocalFlag, opts ...Option) (*SplitProvider, error) {
+	data, err := localFlagsYAML(flags)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.CreateTemp("", "split-localhost-*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
+	opts = append(opts, WithSDKConfig(func(cfg *conf.SplitSdkConfig) {
+		cfg.LocalhostRefreshEnabled = false
+	}))
+	return newLocalhostProvider(file.Name(), opts, false)
+}
+
+// localFlagsYAML renders flags in the Split localhost YAML format. The output is written as JSON,
+// which is valid YAML and avoids any quoting issues in treatments or configs.
+func localFlagsYAML(flags []LocalFlag) ([]byte, error) {
+	entries := make([]map[string]map[string]any, 0, len(flags))
+	for i, flag := range flags {
+		if flag.Name == "" {
+			return nil, fmt.Errorf("local flag %d: name is required", i)
+		}
+		if flag.Treatment == "" {
+			return nil, fmt.Errorf("local flag %q: treatment is required", flag.Name)
+		}
+		definition := map[string]any{"treatment": flag.Treatment}
+		if len(flag.Keys) > 0 {
+			definition["keys"] = flag.Keys
+		}
+		if flag.Config != "" {
+			definition["config"] = flag.Config
+		}
+		entries = append(entries, map[string]map[string]any{flag.Name: definition})
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("at least one local flag is required")
+	}
+	return json.MarshalIndent(entries, "", "  ")
+}
@@ -0,0 +1,84 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/splitio/go-client/v6/splitio/conf"
+	"github.com/splitio/go-toolkit/v5/logging"
+)
+
+func quietSDK() Option {
+	return WithSDKConfig(func(cfg *conf.SplitSdkConfig) {
+		cfg.LoggerConfig.LogLevel = logging.LevelNone
+	})
+}
+
+func TestNewLocalhostProvider_YAML(t *testing.T) {
+	provider, err := NewLocalhostProvider("./split.yaml", quietSDK())
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := provider.IntEvaluation(context.Background(), "int_feature", 0, openfeature.FlattenedContext{openfeature.TargetingKey: "key"})
+	if result.Value != 32 {
+		t.Errorf("Expected 32 from split.yaml, got %d", result.Value)
+	}
+}
+
+func TestNewLocalhostProvider_LegacyFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flags.split")
+	if err := os.WriteFile(path, []byte("# legacy format\nlegacy_feature on\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	provider, err := NewLocalhostProvider(path, quietSDK())
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := provider.BooleanEvaluation(context.Background(), "legacy_feature", false, openfeature.FlattenedContext{openfeature.TargetingKey: "anyone"})
+	if result.Value != true {
+		t.Errorf("Expected legacy_feature to be on, got %+v", result)
+	}
+}
+
+func TestNewLocalhostProvider_MissingFile(t *testing.T) {
+	if _, err := NewLocalhostProvider(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Expected an error for a missing split file")
+	}
+}
+
+func TestNewLocalhostProviderFromDefinitions(t *testing.T) {
+	provider, err := NewLocalhostProviderFromDefinitions([]LocalFlag{
+		{Name: "checkout", Treatment: "v2", Keys: []string{"beta-user"}, Config: `{"color": "blue"}`},
+		{Name: "checkout", Treatment: "v1"},
+		{Name: "limit", Treatment: "100"},
+	}, quietSDK())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	beta := provider.StringEvaluation(ctx, "checkout", "", openfeature.FlattenedContext{openfeature.TargetingKey: "beta-user"})
+	if beta.Value != "v2" || beta.FlagMetadata[flagMetadataConfigKey] != `{"color": "blue"}` {
+		t.Errorf("Unexpected result for whitelisted key %+v", beta)
+	}
+	other := provider.StringEvaluation(ctx, "checkout", "", openfeature.FlattenedContext{openfeature.TargetingKey: "someone"})
+	if other.Value != "v1" {
+		t.Errorf("Expected v1 for other keys, got %q", other.Value)
+	}
+	limit := provider.IntEvaluation(ctx, "limit", 0, openfeature.FlattenedContext{openfeature.TargetingKey: "someone"})
+	if limit.Value != 100 {
+		t.Errorf("Expected 100, got %d", limit.Value)
+	}
+}
+
+func TestNewLocalhostProviderFromDefinitions_Invalid(t *testing.T) {
+	if _, err := NewLocalhostProviderFromDefinitions(nil); err == nil {
+		t.Error("Expected an error without definitions")
+	}
+	if _, err := NewLocalhostProviderFromDefinitions([]LocalFlag{{Name: "flag"}}); err == nil {
+		t.Error("Expected an error for a definition without treatment")
+	}
+}
@@ -0,0 +1,195 @@
+package split_openfeature_provider_go
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/splitio/go-client/v6/splitio/client"
+	"github.com/splitio/go-client/v6/splitio/conf"
+	"gopkg.in/yaml.v3"
+)
+
+// localhostWatcher polls a localhost split file and, when its contents change, loads them into a new
+// Split client that replaces the one serving the provider.
+type localhostWatcher struct {
+	provider *SplitProvider
+	path     string
+	cfg      *conf.SplitSdkConfig
+	interval time.Duration
+
+	// The fields below are only used by the watcher goroutine.
+	sum   [sha256.Size]byte
+	flags map[string]string
+	// retired is the factory replaced by the last reload. It is destroyed on the next check so
+	// evaluations still running against its client can finish.
+	retired *client.SplitFactory
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// watchLocalhostFile starts watching path, which the provider's current factory was created from
+// with cfg.
+func (p *SplitProvider) watchLocalhostFile(path string, cfg *conf.SplitSdkConfig, interval time.Duration) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	w := &localhostWatcher{
+		provider: p,
+		path:     path,
+		cfg:      cfg,
+		interval: interval,
+		sum:      sha256.Sum256(data),
+		flags:    localFlagFingerprints(p.factory.Manager(), path, data),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	p.watcher = w
+	go w.run()
+	return nil
+}
+
+func (w *localhostWatcher) run() {
+	defer close(w.done)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			if w.retired != nil {
+				w.retired.Destroy()
+			}
+			return
+		case <-ticker.C:
+			w.check()
+		}
+	}
+}
+
+// close stops the watcher and waits for it to exit.
+func (w *localhostWatcher) close() {
+	close(w.stop)
+	<-w.done
+}
+
+// check reloads the file if its contents changed. Unreadable or unparsable files are ignored and the
+// current definitions keep being served.
+func (w *localhostWatcher) check() {
+	if w.retired != nil {
+		w.retired.Destroy()
+		w.retired = nil
+	}
+	data, err := os.ReadFile(w.path)
+	if err != nil {
+		return
+	}
+	sum := sha256.Sum256(data)
+	if sum == w.sum {
+		return
+	}
+	if isYAMLSplitFile(w.path) {
+		if _, err := parseLocalhostYAML(data); err != nil {
+			return
+		}
+	}
+	factory, err := client.NewSplitFactory(localhostAPIKey, w.cfg)
+	if err != nil {
+		return
+	}
+	if err := factory.Client().BlockUntilReady(readyTimeoutSeconds); err != nil {
+		factory.Destroy()
+		return
+	}
+	w.sum = sum
+	flags := localFlagFingerprints(factory.Manager(), w.path, data)
+	changed := changedFlags(w.flags, flags)
+	w.flags = flags
+	w.retired = w.provider.swapFactory(factory)
+	if len(changed) > 0 {
+		w.provider.emit(openfeature.ProviderConfigChange, openfeature.ProviderEventDetails{
+			Message:     "localhost split file reloaded",
+			FlagChanges: changed,
+		})
+	}
+}
+
+// swapFactory makes factory's client serve evaluations and returns the factory it replaces. Results
+// cached from the previous client are discarded, whether or not any flag changed.
+func (p *SplitProvider) swapFactory(factory *client.SplitFactory) *client.SplitFactory {
+	p.mu.Lock()
+	previous := p.factory
+	p.factory = factory
+	p.splitClient.Store(factory.Client())
+	p.mu.Unlock()
+	p.invalidateCaches()
+	return previous
+}
+
+func isYAMLSplitFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+func parseLocalhostYAML(data []byte) ([]map[string]map[string]any, error) {
+	var entries []map[string]map[string]any
+	err := yaml.Unmarshal(data, &entries)
+	return entries, err
+}
+
+// localFlagFingerprints returns, per flag name, a string that changes whenever the flag definition
+// changes. It combines the manager view (treatments, configs, default treatment, ...) with, for YAML
+// files, the raw file entries of the flag, since whitelisted keys are not visible in the manager.
+func localFlagFingerprints(manager *client.SplitManager, path string, data []byte) map[string]string {
+	fingerprints := make(map[string]string)
+	for _, view := range manager.Splits() {
+		encoded, _ := json.Marshal(struct {
+			Treatments       []string
+			Configs          map[string]string
+			DefaultTreatment string
+			Killed           bool
+			ChangeNumber     int64
+			Sets             []string
+		}{view.Treatments, view.Configs, view.DefaultTreatment, view.Killed, view.ChangeNumber, view.Sets})
+		fingerprints[view.Name] = string(encoded)
+	}
+	if !isYAMLSplitFile(path) {
+		return fingerprints
+	}
+	entries, err := parseLocalhostYAML(data)
+	if err != nil {
+		return fingerprints
+	}
+	for _, entry := range entries {
+		for name, definition := range entry {
+			encoded, _ := json.Marshal(definition)
+			fingerprints[name] += "|" + string(encoded)
+		}
+	}
+	return fingerprints
+}
+
+// changedFlags returns the sorted names of flags added, removed or modified between before and after.
+func changedFlags(before, after map[string]string) []string {
+	var changed []string
+	for name, fingerprint := range after {
+		if previous, ok := before[name]; !ok || previous != fingerprint {
+			changed = append(changed, name)
+		}
+	}
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
@@ -0,0 +1,132 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+const watchedSplitFile = `
+- feature_a:
+    treatment: "on"
+- feature_b:
+    treatment: "on"
+    keys: "beta"
+- feature_b:
+    treatment: "off"
+- feature_c:
+    treatment: "v1"
+`
+
+// Changes the treatment of feature_a, the keys of feature_b, removes feature_c and adds feature_d.
+const watchedSplitFileUpdated = `
+- feature_a:
+    treatment: "off"
+- feature_b:
+    treatment: "on"
+    keys: "other"
+- feature_b:
+    treatment: "off"
+- feature_d:
+    treatment: "v2"
+`
+
+func waitForEvent(t *testing.T, events <-chan openfeature.Event) openfeature.Event {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for provider event")
+	}
+	return openfeature.Event{}
+}
+
+func TestLocalhostFileWatch_EmitsConfigurationChanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "split.yaml")
+	if err := os.WriteFile(path, []byte(watchedSplitFile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	provider, err := NewLocalhostProvider(path, quietSDK(), WithLocalhostFileWatch(20*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+	ctx := context.Background()
+	beta := openfeature.FlattenedContext{openfeature.TargetingKey: "beta"}
+
+	if result := provider.BooleanEvaluation(ctx, "feature_b", false, beta); result.Value != true {
+		t.Fatalf("Expected feature_b on for beta before reload, got %+v", result)
+	}
+
+	if err := os.WriteFile(path, []byte(watchedSplitFileUpdated), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	event := waitForEvent(t, provider.EventChannel())
+
+	if event.EventType != openfeature.ProviderConfigChange {
+		t.Errorf("Expected %s, got %s", openfeature.ProviderConfigChange, event.EventType)
+	}
+	expected := []string{"feature_a", "feature_b", "feature_c", "feature_d"}
+	if !reflect.DeepEqual(event.FlagChanges, expected) {
+		t.Errorf("Expected flag changes %v, got %v", expected, event.FlagChanges)
+	}
+	if result := provider.BooleanEvaluation(ctx, "feature_a", true, beta); result.Value != false {
+		t.Errorf("Expected feature_a off after reload, got %+v", result)
+	}
+	if result := provider.BooleanEvaluation(ctx, "feature_b", true, beta); result.Value != false {
+		t.Errorf("Expected feature_b off for beta after reload, got %+v", result)
+	}
+	if result := provider.StringEvaluation(ctx, "feature_d", "", beta); result.Value != "v2" {
+		t.Errorf("Expected feature_d to be served after reload, got %+v", result)
+	}
+}
+
+func TestLocalhostFileWatch_IgnoresInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "split.yaml")
+	if err := os.WriteFile(path, []byte(watchedSplitFile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	provider, err := NewLocalhostProvider(path, quietSDK(), WithLocalhostFileWatch(20*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+
+	if err := os.WriteFile(path, []byte("- feature_a: [unclosed"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	select {
+	case event := <-provider.EventChannel():
+		t.Errorf("Unexpected event for an invalid file: %+v", event)
+	default:
+	}
+	result := provider.BooleanEvaluation(context.Background(), "feature_a", false, openfeature.FlattenedContext{openfeature.TargetingKey: "key"})
+	if result.Value != true {
+		t.Errorf("Expected the previous definitions to keep being served, got %+v", result)
+	}
+}
+
+func TestChangedFlags(t *testing.T) {
+	before := map[string]string{"a": "1", "b": "1", "c": "1"}
+	after := map[string]string{"a": "1", "b": "2", "d": "1"}
+	if changed := changedFlags(before, after); !reflect.DeepEqual(changed, []string{"b", "c", "d"}) {
+		t.Errorf("Unexpected changed flags %v", changed)
+	}
+	if changed := changedFlags(before, before); len(changed) != 0 {
+		t.Errorf("Expected no changes, got %v", changed)
+	}
+}
+
+func TestLocalhostFileWatch_InvalidInterval(t *testing.T) {
+	if _, err := NewLocalhostProvider("./split.yaml", WithLocalhostFileWatch(-time.Second)); err == nil {
+		t.Error("Expected an error for a negative interval")
+	}
+}
@@ -0,0 +1,43 @@
+package split_openfeature_provider_go
+
+import (
+	"sync"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// maxCachedMetadata bounds the number of distinct treatment configs whose FlagMetadata is reused.
+// Configs beyond the bound still work; their metadata is just built per evaluation.
+const maxCachedMetadata = 1024
+
+// metadataCache reuses the FlagMetadata built for each distinct treatment config so successful
+// evaluations of flags with configs do not allocate a new map every time. The maps are shared
+// between evaluations and must be treated as read-only.
+type metadataCache struct {
+	mu      sync.RWMutex
+	entries map[string]openfeature.FlagMetadata
+}
+
+func newMetadataCache() *metadataCache {
+	return &metadataCache{entries: make(map[string]openfeature.FlagMetadata)}
+}
+
+// forConfig returns the FlagMetadata for a Split treatment config, or nil if there is no config.
+func (c *metadataCache) forConfig(config *string) openfeature.FlagMetadata {
+	if config == nil || *config == "" {
+		return nil
+	}
+	c.mu.RLock()
+	meta, ok := c.entries[*config]
+	c.mu.RUnlock()
+	if ok {
+		return meta
+	}
+	meta = flagMetadataWithConfig(*config)
+	c.mu.Lock()
+	if len(c.entries) < maxCachedMetadata {
+		c.entries[*config] = meta
+	}
+	c.mu.Unlock()
+	return meta
+}
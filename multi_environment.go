@@ -0,0 +1,388 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// environmentMetadataKey is the event metadata key holding the environment an event comes from.
+const environmentMetadataKey = "environment"
+
+// EnvironmentResolver selects the Split environment for an evaluation or tracking call. It returns
+// false when it cannot tell, in which case the default environment (if any) is used.
+type EnvironmentResolver func(ctx context.Context, flatCtx openfeature.FlattenedContext) (string, bool)
+
+// EnvironmentFromAttribute returns a resolver that reads the environment name from the string
+// evaluation context attribute with the given name.
+func EnvironmentFromAttribute(attribute string) EnvironmentResolver {
+	return func(ctx context.Context, flatCtx openfeature.FlattenedContext) (string, bool) {
+		name, ok := flatCtx[attribute].(string)
+		return name, ok && name != ""
+	}
+}
+
+// MultiEnvironmentOption configures a MultiEnvironmentProvider.
+type MultiEnvironmentOption func(*MultiEnvironmentProvider)
+
+// WithDefaultEnvironment sets the environment used when the resolver cannot select one.
+func WithDefaultEnvironment(name string) MultiEnvironmentOption {
+	return func(m *MultiEnvironmentProvider) {
+		m.defaultEnvironment = name
+	}
+}
+
+// MultiEnvironmentProvider routes each evaluation and tracking call to one of several SplitProviders,
+// typically one per Split environment (SDK key), chosen by an EnvironmentResolver. It implements the
+// same OpenFeature interfaces as SplitProvider: initialization and shutdown apply to every
+// environment, and events from all environments are forwarded with EventMetadata["environment"] set.
+//
+// The readiness of each environment is tracked separately (see EnvironmentState). The provider as a
+// whole is READY when every environment is, in ERROR when any environment is, and STALE otherwise:
+// PROVIDER_READY, PROVIDER_STALE and PROVIDER_ERROR events of an environment are only forwarded when
+// they change that overall state.
+//
+// Resolvers see the evaluation context but not the OpenFeature domain of the client. To route by
+// domain, register each environment's SplitProvider for its domain with openfeature.SetNamedProvider
+// instead.
+type MultiEnvironmentProvider struct {
+	environments       map[string]*SplitProvider
+	resolver           EnvironmentResolver
+	defaultEnvironment string
+	hooks              []openfeature.Hook
+	events             chan openfeature.Event
+
+	mu sync.Mutex
+	// states holds the state of each environment, as last reported by its events.
+	states map[string]openfeature.State
+	// state is the overall state, derived from states by overallState.
+	state openfeature.State
+
+	stop      chan struct{}
+	forwarded sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// NewMultiEnvironmentProvider creates a provider routing between environments, keyed by name, using
+// resolver. The provider takes ownership of the environments: shutting it down shuts them down.
+func NewMultiEnvironmentProvider(environments map[string]*SplitProvider, resolver EnvironmentResolver, opts ...MultiEnvironmentOption) (*MultiEnvironmentProvider, error) {
+	if len(environments) == 0 {
+		return nil, errors.New("at least one Split environment is required")
+	}
+	if resolver == nil {
+		return nil, errors.New("environment resolver cannot be nil")
+	}
+	m := &MultiEnvironmentProvider{
+		environments: make(map[string]*SplitProvider, len(environments)),
+		resolver:     resolver,
+		events:       make(chan openfeature.Event, eventBufferSize),
+		states:       make(map[string]openfeature.State, len(environments)),
+		stop:         make(chan struct{}),
+	}
+	for name, provider := range environments {
+		if provider == nil {
+			return nil, fmt.Errorf("Split provider for environment %q cannot be nil", name)
+		}
+		m.environments[name] = provider
+		m.states[name] = provider.state()
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(m)
+		}
+	}
+	if _, ok := m.environments[m.defaultEnvironment]; m.defaultEnvironment != "" && !ok {
+		return nil, fmt.Errorf("default environment %q is not one of the environments", m.defaultEnvironment)
+	}
+	m.hooks = []openfeature.Hook{&environmentHook{provider: m}}
+	m.state = m.overallState()
+	if m.state == openfeature.StaleState {
+		m.events <- openfeature.Event{
+			ProviderName: providerName,
+			EventType:    openfeature.ProviderStale,
+			ProviderEventDetails: openfeature.ProviderEventDetails{
+				Message: "Split environments are stale: " + strings.Join(m.environmentsIn(openfeature.StaleState), ", "),
+			},
+		}
+	}
+	for name, provider := range m.environments {
+		m.forwarded.Add(1)
+		go m.forwardEvents(name, provider)
+	}
+	return m, nil
+}
+
+// Environment returns the provider of the named environment.
+func (m *MultiEnvironmentProvider) Environment(name string) (*SplitProvider, bool) {
+	provider, ok := m.environments[name]
+	return provider, ok
+}
+
+// EnvironmentState returns the state of the named environment, as last reported by its events:
+// READY, STALE while it serves a snapshot (see WithSnapshot), or ERROR.
+func (m *MultiEnvironmentProvider) EnvironmentState(name string) (openfeature.State, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state, ok := m.states[name]
+	return state, ok
+}
+
+// overallState derives the state of the provider from the state of its environments. m.mu must be
+// held, or m not yet shared.
+func (m *MultiEnvironmentProvider) overallState() openfeature.State {
+	state := openfeature.ReadyState
+	for _, s := range m.states {
+		switch {
+		case s == openfeature.ErrorState || s == openfeature.FatalState:
+			return openfeature.ErrorState
+		case s != openfeature.ReadyState:
+			state = openfeature.StaleState
+		}
+	}
+	return state
+}
+
+// environmentsIn returns the sorted names of the environments in state. m.mu must be held, or m not
+// yet shared.
+func (m *MultiEnvironmentProvider) environmentsIn(state openfeature.State) []string {
+	var names []string
+	for name, s := range m.states {
+		if s == state {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Environments returns the environment names, sorted.
+func (m *MultiEnvironmentProvider) Environments() []string {
+	names := make([]string, 0, len(m.environments))
+	for name := range m.environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (m *MultiEnvironmentProvider) Metadata() openfeature.Metadata {
+	return openfeature.Metadata{
+		Name: providerName,
+	}
+}
+
+// selectEnvironment returns the provider for the call, or nil if no environment applies.
+func (m *MultiEnvironmentProvider) selectEnvironment(ctx context.Context, flatCtx openfeature.FlattenedContext) *SplitProvider {
+	name, ok := m.resolver(ctx, flatCtx)
+	if !ok {
+		name = m.defaultEnvironment
+	}
+	return m.environments[name]
+}
+
+func detailNoEnvironment() openfeature.ProviderResolutionDetail {
+	return openfeature.ProviderResolutionDetail{
+		ResolutionError: openfeature.NewInvalidContextResolutionError("no Split environment matches the evaluation context"),
+		Reason:          openfeature.ErrorReason,
+	}
+}
+
+func (m *MultiEnvironmentProvider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, flatCtx openfeature.FlattenedContext) openfeature.BoolResolutionDetail {
+	provider := m.selectEnvironment(ctx, flatCtx)
+	if provider == nil {
+		return openfeature.BoolResolutionDetail{Value: defaultValue, ProviderResolutionDetail: detailNoEnvironment()}
+	}
+	return provider.BooleanEvaluation(ctx, flag, defaultValue, flatCtx)
+}
+
+func (m *MultiEnvironmentProvider) StringEvaluation(ctx context.Context, flag string, defaultValue string, flatCtx openfeature.FlattenedContext) openfeature.StringResolutionDetail {
+	provider := m.selectEnvironment(ctx, flatCtx)
+	if provider == nil {
+		return openfeature.StringResolutionDetail{Value: defaultValue, ProviderResolutionDetail: detailNoEnvironment()}
+	}
+	return provider.StringEvaluation(ctx, flag, defaultValue, flatCtx)
+}
+
+func (m *MultiEnvironmentProvider) FloatEvaluation(ctx context.Context, flag string, defaultValue float64, flatCtx openfeature.FlattenedContext) openfeature.FloatResolutionDetail {
+	provider := m.selectEnvironment(ctx, flatCtx)
+	if provider == nil {
+		return openfeature.FloatResolutionDetail{Value: defaultValue, ProviderResolutionDetail: detailNoEnvironment()}
+	}
+	return provider.FloatEvaluation(ctx, flag, defaultValue, flatCtx)
+}
+
+func (m *MultiEnvironmentProvider) IntEvaluation(ctx context.Context, flag string, defaultValue int64, flatCtx openfeature.FlattenedContext) openfeature.IntResolutionDetail {
+	provider := m.selectEnvironment(ctx, flatCtx)
+	if provider == nil {
+		return openfeature.IntResolutionDetail{Value: defaultValue, ProviderResolutionDetail: detailNoEnvironment()}
+	}
+	return provider.IntEvaluation(ctx, flag, defaultValue, flatCtx)
+}
+
+func (m *MultiEnvironmentProvider) ObjectEvaluation(ctx context.Context, flag string, defaultValue interface{}, flatCtx openfeature.FlattenedContext) openfeature.InterfaceResolutionDetail {
+	provider := m.selectEnvironment(ctx, flatCtx)
+	if provider == nil {
+		return openfeature.InterfaceResolutionDetail{Value: defaultValue, ProviderResolutionDetail: detailNoEnvironment()}
+	}
+	return provider.ObjectEvaluation(ctx, flag, defaultValue, flatCtx)
+}
+
+// Hooks returns a hook that runs the hooks of the environment selected for each evaluation.
+func (m *MultiEnvironmentProvider) Hooks() []openfeature.Hook {
+	return m.hooks
+}
+
+// Track sends the event to the environment selected for evaluationContext. Events for which no
+// environment applies are dropped.
+func (m *MultiEnvironmentProvider) Track(ctx context.Context, trackingEventName string, evaluationContext openfeature.EvaluationContext, details openfeature.TrackingEventDetails) {
+	if provider := m.selectEnvironment(ctx, flattenEvaluationContext(evaluationContext)); provider != nil {
+		provider.Track(ctx, trackingEventName, evaluationContext, details)
+	}
+}
+
+// Init initializes every environment and returns an error naming the ones that failed.
+func (m *MultiEnvironmentProvider) Init(evaluationContext openfeature.EvaluationContext) error {
+	var failures []string
+	for _, name := range m.Environments() {
+		if err := m.environments[name].Init(evaluationContext); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+	if len(failures) > 0 {
+		return errors.New("Split environments failed to initialize: " + strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// Shutdown shuts down every environment and stops forwarding their events.
+func (m *MultiEnvironmentProvider) Shutdown() {
+	m.closeOnce.Do(func() {
+		close(m.stop)
+		m.forwarded.Wait()
+		for _, provider := range m.environments {
+			provider.Shutdown()
+		}
+	})
+}
+
+// EventChannel implements openfeature.EventHandler.
+func (m *MultiEnvironmentProvider) EventChannel() <-chan openfeature.Event {
+	return m.events
+}
+
+// forwardEvents relays the events of one environment, tagging them with its name. State events
+// update the environment's state and are only relayed when they change the overall state.
+func (m *MultiEnvironmentProvider) forwardEvents(name string, provider *SplitProvider) {
+	defer m.forwarded.Done()
+	for {
+		select {
+		case <-m.stop:
+			return
+		case event := <-provider.EventChannel():
+			eventType, relay := m.updateState(name, event.EventType)
+			if !relay {
+				continue
+			}
+			event.EventType = eventType
+			metadata := make(map[string]any, len(event.EventMetadata)+1)
+			for k, v := range event.EventMetadata {
+				metadata[k] = v
+			}
+			metadata[environmentMetadataKey] = name
+			event.EventMetadata = metadata
+			select {
+			case m.events <- event:
+			default:
+			}
+		}
+	}
+}
+
+// stateEvents maps the states of the provider to the events reporting them.
+var stateEvents = map[openfeature.State]openfeature.EventType{
+	openfeature.ReadyState: openfeature.ProviderReady,
+	openfeature.StaleState: openfeature.ProviderStale,
+	openfeature.ErrorState: openfeature.ProviderError,
+}
+
+// updateState records the state reported by an event of the named environment. It returns the type
+// to relay the event with and whether to relay it at all: events that do not report a state are
+// relayed as they are, the others only when the overall state changes, as the event reporting the
+// new overall state.
+func (m *MultiEnvironmentProvider) updateState(name string, eventType openfeature.EventType) (openfeature.EventType, bool) {
+	var state openfeature.State
+	switch eventType {
+	case openfeature.ProviderReady:
+		state = openfeature.ReadyState
+	case openfeature.ProviderStale:
+		state = openfeature.StaleState
+	case openfeature.ProviderError:
+		state = openfeature.ErrorState
+	default:
+		return eventType, true
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.states[name] = state
+	overall := m.overallState()
+	if overall == m.state {
+		return eventType, false
+	}
+	m.state = overall
+	return stateEvents[overall], true
+}
+
+// environmentHook runs the hooks of the environment selected for the evaluation.
+type environmentHook struct {
+	provider *MultiEnvironmentProvider
+}
+
+func (h *environmentHook) hooksFor(ctx context.Context, hookContext openfeature.HookContext) []openfeature.Hook {
+	provider := h.provider.selectEnvironment(ctx, flattenEvaluationContext(hookContext.EvaluationContext()))
+	if provider == nil {
+		return nil
+	}
+	return provider.Hooks()
+}
+
+func (h *environmentHook) Before(ctx context.Context, hookContext openfeature.HookContext, hookHints openfeature.HookHints) (*openfeature.EvaluationContext, error) {
+	var result *openfeature.EvaluationContext
+	for _, hook := range h.hooksFor(ctx, hookContext) {
+		evalCtx, err := hook.Before(ctx, hookContext, hookHints)
+		if err != nil {
+			return evalCtx, err
+		}
+		if evalCtx != nil {
+			result = evalCtx
+			hookContext = openfeature.NewHookContext(hookContext.FlagKey(), hookContext.FlagType(), hookContext.DefaultValue(),
+				hookContext.ClientMetadata(), hookContext.ProviderMetadata(), *evalCtx)
+		}
+	}
+	return result, nil
+}
+
+func (h *environmentHook) After(ctx context.Context, hookContext openfeature.HookContext, flagEvaluationDetails openfeature.InterfaceEvaluationDetails, hookHints openfeature.HookHints) error {
+	for _, hook := range h.hooksFor(ctx, hookContext) {
+		if err := hook.After(ctx, hookContext, flagEvaluationDetails, hookHints); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *environmentHook) Error(ctx context.Context, hookContext openfeature.HookContext, err error, hookHints openfeature.HookHints) {
+	for _, hook := range h.hooksFor(ctx, hookContext) {
+		hook.Error(ctx, hookContext, err, hookHints)
+	}
+}
+
+func (h *environmentHook) Finally(ctx context.Context, hookContext openfeature.HookContext, flagEvaluationDetails openfeature.InterfaceEvaluationDetails, hookHints openfeature.HookHints) {
+	for _, hook := range h.hooksFor(ctx, hookContext) {
+		hook.Finally(ctx, hookContext, flagEvaluationDetails, hookHints)
+	}
+}
@@ -0,0 +1,185 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+func createMultiEnvironmentProvider(t *testing.T, opts ...MultiEnvironmentOption) *MultiEnvironmentProvider {
+	t.Helper()
+	staging, err := NewLocalhostProviderFromDefinitions([]LocalFlag{{Name: "checkout", Treatment: "v2"}}, quietSDK(), WithContextValidation())
+	if err != nil {
+		t.Fatal(err)
+	}
+	production, err := NewLocalhostProviderFromDefinitions([]LocalFlag{{Name: "checkout", Treatment: "v1"}}, quietSDK())
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider, err := NewMultiEnvironmentProvider(map[string]*SplitProvider{
+		"staging":    staging,
+		"production": production,
+	}, EnvironmentFromAttribute("env"), opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(provider.Shutdown)
+	return provider
+}
+
+func TestMultiEnvironmentProvider_RoutesByAttribute(t *testing.T) {
+	provider := createMultiEnvironmentProvider(t)
+	ctx := context.Background()
+
+	for env, expected := range map[string]string{"staging": "v2", "production": "v1"} {
+		flatCtx := openfeature.FlattenedContext{openfeature.TargetingKey: "key", "env": env}
+		if result := provider.StringEvaluation(ctx, "checkout", "default", flatCtx); result.Value != expected {
+			t.Errorf("Expected %s from %s, got %+v", expected, env, result)
+		}
+	}
+}
+
+func TestMultiEnvironmentProvider_NoEnvironment(t *testing.T) {
+	provider := createMultiEnvironmentProvider(t)
+	flatCtx := openfeature.FlattenedContext{openfeature.TargetingKey: "key", "env": "qa"}
+
+	result := provider.StringEvaluation(context.Background(), "checkout", "default", flatCtx)
+	if result.Value != "default" || result.Reason != openfeature.ErrorReason {
+		t.Errorf("Expected the default value with an error, got %+v", result)
+	}
+	if result.ResolutionDetail().ErrorCode != openfeature.InvalidContextCode {
+		t.Errorf("Expected %s, got %s", openfeature.InvalidContextCode, result.ResolutionDetail().ErrorCode)
+	}
+}
+
+func TestMultiEnvironmentProvider_DefaultEnvironment(t *testing.T) {
+	provider := createMultiEnvironmentProvider(t, WithDefaultEnvironment("production"))
+	flatCtx := openfeature.FlattenedContext{openfeature.TargetingKey: "key"}
+
+	if result := provider.StringEvaluation(context.Background(), "checkout", "default", flatCtx); result.Value != "v1" {
+		t.Errorf("Expected the default environment to serve v1, got %+v", result)
+	}
+}
+
+func TestMultiEnvironmentProvider_InvalidConfiguration(t *testing.T) {
+	if _, err := NewMultiEnvironmentProvider(nil, EnvironmentFromAttribute("env")); err == nil {
+		t.Error("Expected an error without environments")
+	}
+	if _, err := NewMultiEnvironmentProvider(map[string]*SplitProvider{"a": nil}, EnvironmentFromAttribute("env")); err == nil {
+		t.Error("Expected an error for a nil environment")
+	}
+	provider, err := NewLocalhostProviderFromDefinitions([]LocalFlag{{Name: "checkout", Treatment: "v1"}}, quietSDK())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+	environments := map[string]*SplitProvider{"a": provider}
+	if _, err := NewMultiEnvironmentProvider(environments, nil); err == nil {
+		t.Error("Expected an error for a nil resolver")
+	}
+	if _, err := NewMultiEnvironmentProvider(environments, EnvironmentFromAttribute("env"), WithDefaultEnvironment("b")); err == nil {
+		t.Error("Expected an error for an unknown default environment")
+	}
+}
+
+func TestMultiEnvironmentProvider_ForwardsEvents(t *testing.T) {
+	provider := createMultiEnvironmentProvider(t)
+	staging, _ := provider.Environment("staging")
+
+	staging.emit(openfeature.ProviderConfigChange, openfeature.ProviderEventDetails{FlagChanges: []string{"checkout"}})
+	event := waitForEvent(t, provider.EventChannel())
+
+	if event.EventType != openfeature.ProviderConfigChange {
+		t.Errorf("Expected %s, got %s", openfeature.ProviderConfigChange, event.EventType)
+	}
+	if event.EventMetadata[environmentMetadataKey] != "staging" {
+		t.Errorf("Expected the event to be tagged with its environment, got %v", event.EventMetadata)
+	}
+}
+
+func waitForState(t *testing.T, provider *MultiEnvironmentProvider, name string, expected openfeature.State) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(time.Millisecond) {
+		state, _ := provider.EnvironmentState(name)
+		if state == expected {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s to be %s, got %s", name, expected, state)
+		}
+	}
+}
+
+func TestMultiEnvironmentProvider_EnvironmentState(t *testing.T) {
+	provider := createMultiEnvironmentProvider(t)
+	staging, _ := provider.Environment("staging")
+	production, _ := provider.Environment("production")
+	expectStates := func(stagingState, productionState openfeature.State) {
+		t.Helper()
+		if state, _ := provider.EnvironmentState("staging"); state != stagingState {
+			t.Errorf("Expected staging to be %s, got %s", stagingState, state)
+		}
+		if state, _ := provider.EnvironmentState("production"); state != productionState {
+			t.Errorf("Expected production to be %s, got %s", productionState, state)
+		}
+	}
+	expectEvent := func(eventType openfeature.EventType, environment string) {
+		t.Helper()
+		event := waitForEvent(t, provider.EventChannel())
+		if event.EventType != eventType || event.EventMetadata[environmentMetadataKey] != environment {
+			t.Errorf("Expected %s from %s, got %s from %v", eventType, environment, event.EventType, event.EventMetadata[environmentMetadataKey])
+		}
+	}
+	expectStates(openfeature.ReadyState, openfeature.ReadyState)
+
+	staging.emit(openfeature.ProviderStale, openfeature.ProviderEventDetails{})
+	expectEvent(openfeature.ProviderStale, "staging")
+	expectStates(openfeature.StaleState, openfeature.ReadyState)
+
+	production.emit(openfeature.ProviderError, openfeature.ProviderEventDetails{})
+	expectEvent(openfeature.ProviderError, "production")
+	// Recovering production leaves staging stale, so the provider as a whole becomes stale.
+	production.emit(openfeature.ProviderReady, openfeature.ProviderEventDetails{})
+	expectEvent(openfeature.ProviderStale, "production")
+	// Further changes that keep an environment stale are not relayed.
+	production.emit(openfeature.ProviderStale, openfeature.ProviderEventDetails{})
+	waitForState(t, provider, "production", openfeature.StaleState)
+	staging.emit(openfeature.ProviderReady, openfeature.ProviderEventDetails{})
+	waitForState(t, provider, "staging", openfeature.ReadyState)
+	production.emit(openfeature.ProviderReady, openfeature.ProviderEventDetails{})
+	expectEvent(openfeature.ProviderReady, "production")
+	expectStates(openfeature.ReadyState, openfeature.ReadyState)
+
+	if _, ok := provider.EnvironmentState("unknown"); ok {
+		t.Error("Expected no state for an unknown environment")
+	}
+}
+
+func TestMultiEnvironmentProvider_RoutesHooks(t *testing.T) {
+	provider := createMultiEnvironmentProvider(t)
+	if len(provider.Hooks()) != 1 {
+		t.Fatalf("Expected one routing hook, got %d", len(provider.Hooks()))
+	}
+	hook := provider.Hooks()[0]
+	evalCtx := openfeature.NewEvaluationContext("", map[string]any{"env": "staging"})
+	hookContext := openfeature.NewHookContext("checkout", openfeature.String, "default",
+		openfeature.NewClientMetadata(""), provider.Metadata(), evalCtx)
+
+	_, err := hook.Before(context.Background(), hookContext, openfeature.HookHints{})
+	if err == nil {
+		t.Error("Expected the staging validation hook to reject a missing targeting key")
+	}
+}
+
+func TestMultiEnvironmentProvider_Environments(t *testing.T) {
+	provider := createMultiEnvironmentProvider(t)
+	names := provider.Environments()
+	if len(names) != 2 || names[0] != "production" || names[1] != "staging" {
+		t.Errorf("Unexpected environments %v", names)
+	}
+	if err := provider.Init(openfeature.EvaluationContext{}); err != nil {
+		t.Errorf("Unexpected init error: %v", err)
+	}
+}
@@ -0,0 +1,190 @@
+package split_openfeature_provider_go
+
+import (
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/splitio/go-client/v6/splitio/client"
+	"github.com/splitio/go-client/v6/splitio/conf"
+)
+
+// Option configures optional behavior of a SplitProvider.
+type Option func(*providerOptions)
+
+// providerOptions holds the settings collected from Option values before the provider is built.
+type providerOptions struct {
+	contextLimits ContextLimits
+	// contextValidation registers the context validation hook, see WithContextValidation.
+	contextValidation bool
+	recorder          *EvaluationRecorder
+	fileWatch         time.Duration
+	manager           *client.SplitManager
+	fallbacks         FallbackTreatments
+	contextMapper     ContextMapper
+	keyPolicy         TargetingKeyPolicy
+	keyDeriver        KeyDeriver
+	readyTimeout      time.Duration
+	trackReporter     TrackReporter
+
+	contextTracking contextTracking
+	exposures       *ExposureTracking
+	dedup           *dedupSettings
+	impressions     impressionSettings
+	decoders        map[reflect.Type]any
+	variants        *VariantConstraints
+	schemas         map[string]FlagSchema
+	// compiledSchemas holds schemas compiled by newProviderOptions.
+	compiledSchemas map[string]compiledSchema
+
+	snapshotPath     string
+	snapshotInterval time.Duration
+
+	// sdkConfig holds adjustments applied to the Split SDK configuration by the constructors
+	// that create the Split factory themselves.
+	sdkConfig []func(*conf.SplitSdkConfig)
+}
+
+func defaultProviderOptions() providerOptions {
+	return providerOptions{
+		contextLimits: DefaultContextLimits(),
+		readyTimeout:  readyTimeoutSeconds * time.Second,
+	}
+}
+
+// newProviderOptions applies opts over the defaults and validates the result.
+func newProviderOptions(opts []Option) (providerOptions, error) {
+	o := defaultProviderOptions()
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	if err := o.contextLimits.validate(); err != nil {
+		return providerOptions{}, err
+	}
+	if err := o.keyPolicy.validate(); err != nil {
+		return providerOptions{}, err
+	}
+	if err := o.fallbacks.validate(); err != nil {
+		return providerOptions{}, err
+	}
+	if err := o.contextTracking.validate(); err != nil {
+		return providerOptions{}, err
+	}
+	if err := o.exposures.validate(); err != nil {
+		return providerOptions{}, err
+	}
+	if err := o.dedup.validate(); err != nil {
+		return providerOptions{}, err
+	}
+	if err := o.impressions.validate(); err != nil {
+		return providerOptions{}, err
+	}
+	if err := validateDecoders(o.decoders); err != nil {
+		return providerOptions{}, err
+	}
+	if err := o.variants.validate(); err != nil {
+		return providerOptions{}, err
+	}
+	if o.fileWatch < 0 {
+		return providerOptions{}, errors.New("localhost file watch interval cannot be negative")
+	}
+	if o.readyTimeout <= 0 {
+		return providerOptions{}, errors.New("ready timeout must be positive")
+	}
+	if err := validateSnapshotOptions(o.snapshotPath, o.snapshotInterval); err != nil {
+		return providerOptions{}, err
+	}
+	var err error
+	if o.compiledSchemas, err = compileSchemas(o.schemas); err != nil {
+		return providerOptions{}, err
+	}
+	return o, nil
+}
+
+// readyTimeoutSeconds returns the ready timeout in whole seconds, as the Split SDK expects, rounding up.
+func (o providerOptions) readyTimeoutSeconds() int {
+	return int((o.readyTimeout + time.Second - 1) / time.Second)
+}
+
+// applySDKConfig applies the SDK configuration adjustments collected from the options to cfg.
+func (o providerOptions) applySDKConfig(cfg *conf.SplitSdkConfig) {
+	o.impressions.apply(cfg)
+	for _, configure := range o.sdkConfig {
+		configure(cfg)
+	}
+}
+
+// WithContextValidation registers a provider hook that validates evaluation contexts before every
+// evaluation with DefaultContextLimits, or the limits given with WithContextLimits. Contexts that
+// would otherwise evaluate, e.g. with attributes Split ignores such as nested maps, then fail with
+// INVALID_CONTEXT. The OpenFeature SDK reports hook errors without error code nor reason, so
+// evaluations rejected by the hook return details with an empty ErrorCode and Reason.
+func WithContextValidation() Option {
+	return func(o *providerOptions) {
+		o.contextValidation = true
+	}
+}
+
+// WithContextLimits sets the limits enforced by the provider's context validation hook and
+// enables it (see WithContextValidation).
+func WithContextLimits(limits ContextLimits) Option {
+	return func(o *providerOptions) {
+		o.contextLimits = limits
+		o.contextValidation = true
+	}
+}
+
+// WithEvaluationRecorder delivers an EvaluationRecord to recorder for every evaluation.
+// See EvaluationRecorder for how it must be registered with the Split SDK.
+func WithEvaluationRecorder(recorder *EvaluationRecorder) Option {
+	return func(o *providerOptions) {
+		if recorder == nil {
+			return
+		}
+		o.recorder = recorder
+		o.sdkConfig = append(o.sdkConfig, func(cfg *conf.SplitSdkConfig) {
+			cfg.Advanced.ImpressionListener = recorder
+		})
+	}
+}
+
+// WithSDKConfig registers a function that adjusts the Split SDK configuration before the factory is
+// created, e.g. to set a logger. It only applies to constructors that create the Split client
+// themselves (NewProviderWithAPIKey, NewLocalhostProvider, ...); functions run in the order given.
+func WithSDKConfig(configure func(cfg *conf.SplitSdkConfig)) Option {
+	return func(o *providerOptions) {
+		if configure != nil {
+			o.sdkConfig = append(o.sdkConfig, configure)
+		}
+	}
+}
+
+// WithLocalhostFileWatch makes NewLocalhostProvider check the split file every interval. When its
+// contents change, the definitions are loaded into a new Split client that replaces the current one
+// and a PROVIDER_CONFIGURATION_CHANGED event listing the added, removed or modified flags is
+// emitted. Files that cannot be read or parsed are ignored until they are fixed. A zero interval
+// disables watching.
+func WithLocalhostFileWatch(interval time.Duration) Option {
+	return func(o *providerOptions) {
+		o.fileWatch = interval
+	}
+}
+
+// WithManager gives a provider built with NewProvider access to the Split manager of the client's
+// factory, enabling Flags, Flag and the features built on them. Constructors that create the Split
+// client use its manager automatically.
+func WithManager(manager *client.SplitManager) Option {
+	return func(o *providerOptions) {
+		o.manager = manager
+	}
+}
+
+// WithReadyTimeout sets how long constructors that create the Split client wait for it to become
+// ready (10 seconds by default). The Split SDK counts whole seconds, so it is rounded up.
+func WithReadyTimeout(timeout time.Duration) Option {
+	return func(o *providerOptions) {
+		o.readyTimeout = timeout
+	}
+}
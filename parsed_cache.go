@@ -0,0 +1,160 @@
+package split_openfeature_provider_go
+
+import (
+	"container/list"
+	"sync"
+)
+
+// maxParsedValues bounds the number of parsed treatments and schema verdicts kept. Payloads beyond
+// the bound evict the least recently used ones.
+const maxParsedValues = 1024
+
+// parsedKey identifies a payload of a flag. Parsing depends on nothing but the payload itself, so
+// entries never go stale: a payload served under a new definition of the flag reuses its entry, and
+// payloads no longer served are eventually evicted.
+type parsedKey struct {
+	flag      string
+	treatment string
+	config    string
+	hasConfig bool
+	// schema tells schema verdicts apart from parsed objects.
+	schema bool
+}
+
+type parsedObject struct {
+	value map[string]interface{}
+	err   error
+}
+
+type parsedEntry struct {
+	key     parsedKey
+	object  parsedObject
+	verdict error
+}
+
+// parsedCache reuses the JSON object parsed from each treatment of a flag, and the verdict of its
+// schemas (see WithFlagSchemas) for each treatment and config, so repeated evaluations do not parse
+// the same payloads again. At most maxParsedValues entries are kept, in least recently used order,
+// and every entry is dropped on PROVIDER_CONFIGURATION_CHANGED. Cached objects are never handed
+// out: callers get deep copies they may modify. A nil parsedCache parses every time.
+type parsedCache struct {
+	mu      sync.Mutex
+	entries map[parsedKey]*list.Element
+	order   *list.List
+}
+
+func newParsedCache() *parsedCache {
+	return &parsedCache{
+		entries: make(map[parsedKey]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// object returns a copy of treatment parsed as a JSON object, as parseObjectTreatment does.
+func (c *parsedCache) object(flag, treatment string) (map[string]interface{}, error) {
+	if c == nil {
+		return parseObjectTreatment(treatment)
+	}
+	k := parsedKey{flag: flag, treatment: treatment}
+	entry, ok := c.get(k)
+	if !ok {
+		entry = &parsedEntry{key: k}
+		entry.object.value, entry.object.err = parseObjectTreatment(treatment)
+		c.put(entry)
+	}
+	if entry.object.err != nil {
+		return nil, entry.object.err
+	}
+	return copyJSONObject(entry.object.value), nil
+}
+
+// checkSchema returns the result of schema.check for the treatment and config of flag.
+func (c *parsedCache) checkSchema(flag string, schema compiledSchema, treatment string, config *string) error {
+	if c == nil {
+		return schema.check(treatment, config)
+	}
+	k := parsedKey{flag: flag, treatment: treatment, schema: true}
+	if config != nil {
+		k.config, k.hasConfig = *config, true
+	}
+	if entry, ok := c.get(k); ok {
+		return entry.verdict
+	}
+	entry := &parsedEntry{key: k, verdict: schema.check(treatment, config)}
+	c.put(entry)
+	return entry.verdict
+}
+
+// get returns the entry stored for k, marking it as the most recently used.
+func (c *parsedCache) get(k parsedKey) (*parsedEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[k]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToBack(elem)
+	return elem.Value.(*parsedEntry), true
+}
+
+// put stores entry, evicting the least recently used entries beyond maxParsedValues.
+func (c *parsedCache) put(entry *parsedEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[entry.key]; ok {
+		c.remove(elem)
+	}
+	c.entries[entry.key] = c.order.PushBack(entry)
+	for c.order.Len() > maxParsedValues {
+		c.remove(c.order.Front())
+	}
+}
+
+// len returns the number of objects and schema verdicts held.
+func (c *parsedCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// invalidate drops every entry.
+func (c *parsedCache) invalidate() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[parsedKey]*list.Element)
+	c.order.Init()
+}
+
+func (c *parsedCache) remove(elem *list.Element) {
+	delete(c.entries, elem.Value.(*parsedEntry).key)
+	c.order.Remove(elem)
+}
+
+// copyJSONObject deep-copies a JSON object as decoded by encoding/json.
+func copyJSONObject(object map[string]interface{}) map[string]interface{} {
+	if object == nil {
+		return nil
+	}
+	copied := make(map[string]interface{}, len(object))
+	for k, v := range object {
+		copied[k] = copyJSONValue(v)
+	}
+	return copied
+}
+
+func copyJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return copyJSONObject(v)
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = copyJSONValue(item)
+		}
+		return copied
+	}
+	return value
+}
@@ -0,0 +1,125 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+func TestParsedCache_ObjectsAreCopies(t *testing.T) {
+	provider, err := NewLocalhostProviderFromDefinitions([]LocalFlag{
+		{Name: "banner", Treatment: `{"color":"blue","sizes":[1,2],"style":{"bold":true}}`},
+	}, quietSDK())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+	ctx := context.Background()
+	user := openfeature.FlattenedContext{openfeature.TargetingKey: "user"}
+	expected := map[string]any{"color": "blue", "sizes": []any{1.0, 2.0}, "style": map[string]any{"bold": true}}
+
+	first := provider.ObjectEvaluation(ctx, "banner", nil, user).Value.(map[string]any)
+	first["color"] = "red"
+	first["sizes"].([]any)[0] = 9.0
+	first["style"].(map[string]any)["bold"] = false
+
+	if second := provider.ObjectEvaluation(ctx, "banner", nil, user).Value; !reflect.DeepEqual(second, expected) {
+		t.Errorf("Expected changes to a served value not to leak into later evaluations, got %v", second)
+	}
+	if typed := Evaluate(ctx, provider, "banner", map[string]any(nil), openfeature.NewEvaluationContext("user", nil)).Value; !reflect.DeepEqual(typed, expected) {
+		t.Errorf("Expected Evaluate to share the parsed value, got %v", typed)
+	}
+	if provider.parsed.len() != 1 {
+		t.Errorf("Expected one parsed treatment, got %d", provider.parsed.len())
+	}
+}
+
+func TestParsedCache_Errors(t *testing.T) {
+	cache := newParsedCache()
+	for i := 0; i < 2; i++ {
+		if _, err := cache.object("banner", "not json"); err == nil {
+			t.Error("Expected invalid treatments to keep failing")
+		}
+	}
+	if _, err := (*parsedCache)(nil).object("banner", `{"color":"blue"}`); err != nil {
+		t.Errorf("Expected a nil cache to parse, got %v", err)
+	}
+}
+
+func TestParsedCache_InvalidatedOnConfigurationChange(t *testing.T) {
+	provider, err := NewLocalhostProviderFromDefinitions([]LocalFlag{
+		{Name: "banner", Treatment: `{"color":"blue"}`, Config: `{"size":0}`},
+	}, quietSDK(), WithFlagSchemas(map[string]FlagSchema{"banner": {Config: bannerConfigSchema}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+
+	result := provider.ObjectEvaluation(context.Background(), "banner", nil, openfeature.FlattenedContext{openfeature.TargetingKey: "user"})
+	if result.ResolutionDetail().ErrorCode != openfeature.ParseErrorCode {
+		t.Fatalf("Expected the config to be rejected, got %+v", result)
+	}
+	if provider.parsed.len() != 1 {
+		t.Fatalf("Expected the schema verdict to be cached, got %d entries", provider.parsed.len())
+	}
+	provider.emit(openfeature.ProviderConfigChange, openfeature.ProviderEventDetails{})
+	if provider.parsed.len() != 0 {
+		t.Error("Expected configuration changes to drop parsed values")
+	}
+}
+
+func TestParsedCache_LeastRecentlyUsed(t *testing.T) {
+	cache := newParsedCache()
+	for i := 0; i < maxParsedValues; i++ {
+		cache.object("banner", fmt.Sprintf(`{"id":%d}`, i))
+	}
+	cache.object("banner", `{"id":0}`)
+	cache.object("banner", `{"id":"new"}`)
+	if cache.len() != maxParsedValues {
+		t.Fatalf("Expected %d entries, got %d", maxParsedValues, cache.len())
+	}
+	if _, ok := cache.get(parsedKey{flag: "banner", treatment: `{"id":0}`}); !ok {
+		t.Error("Expected the recently used entry to be kept")
+	}
+	if _, ok := cache.get(parsedKey{flag: "banner", treatment: `{"id":1}`}); ok {
+		t.Error("Expected the least recently used entry to be evicted")
+	}
+}
+
+func TestParsedCache_RedisConsumer(t *testing.T) {
+	server := miniredis.RunT(t)
+	synchronize(t, server, "myapp")
+	provider, err := NewRedisConsumerProvider(context.Background(), "api-key", redisConfigFor(t, server, "myapp"), quietSDK(),
+		WithFlagSchemas(map[string]FlagSchema{"checkout": {Config: `{"properties": {"color": {"type": "string"}}}`}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+	ctx := context.Background()
+	user := openfeature.FlattenedContext{openfeature.TargetingKey: "user"}
+
+	if result := provider.StringEvaluation(ctx, "checkout", "", user); result.Value != "v1" {
+		t.Fatalf("Expected v1, got %+v", result)
+	}
+	before := server.CommandCount()
+	provider.StringEvaluation(ctx, "checkout", "", user)
+	perEvaluation := server.CommandCount() - before
+	before = server.CommandCount()
+	provider.ObjectEvaluation(ctx, "checkout", nil, user)
+	if commands := server.CommandCount() - before; commands != perEvaluation {
+		t.Errorf("Expected cached payloads not to cost Redis commands, got %d instead of %d", commands, perEvaluation)
+	}
+
+	updated := strings.NewReplacer(`"changeNumber": 1`, `"changeNumber": 2`, `{\"color\":\"grey\"}`, `{\"color\":1}`).Replace(redisCheckoutFlag)
+	if err := server.Set("myapp.SPLITIO.split.checkout", updated); err != nil {
+		t.Fatal(err)
+	}
+	if result := provider.StringEvaluation(ctx, "checkout", "", user); result.ResolutionDetail().ErrorCode != openfeature.ParseErrorCode {
+		t.Errorf("Expected the changed config to be checked, got %+v", result)
+	}
+}
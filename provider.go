@@ -3,278 +3,466 @@ package split_openfeature_provider_go
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
 	"strconv"
+	"sync"
+	"sync/atomic"
 
 	"github.com/open-feature/go-sdk/openfeature"
 	"github.com/splitio/go-client/v6/splitio/client"
 	"github.com/splitio/go-client/v6/splitio/conf"
+	"github.com/splitio/go-split-commons/v9/dtos"
 )
 
 const (
 	// Metadata key for Split treatment config (JSON string), aligned with other Split OpenFeature providers.
 	flagMetadataConfigKey = "config"
+	// Default seconds the constructors that create the Split client wait for it to become ready.
+	readyTimeoutSeconds = 10
+	// Name reported in the provider metadata and events.
+	providerName = "Split"
 )
 
 type SplitProvider struct {
-	client *client.SplitClient
+	// splitClient is the client serving evaluations. It is replaced when localhost definitions are reloaded.
+	splitClient atomic.Pointer[client.SplitClient]
+	// stale is the snapshot served while the Split SDK is not ready, if any (see WithSnapshot).
+	stale     atomic.Pointer[flagSnapshot]
+	hooks     []openfeature.Hook
+	recorder  *EvaluationRecorder
+	fallbacks FallbackTreatments
+	mapper    ContextMapper
+	keyPolicy TargetingKeyPolicy
+	deriver   KeyDeriver
+	reporter  TrackReporter
+	tracking  contextTracking
+	dedup     *dedupCache
+	// quiet evaluates the flags whose impressions are disabled, if any. Shutdown clears it.
+	quiet atomic.Pointer[quietClient]
+	// decoders holds the TreatmentDecoder of each type for Evaluate.
+	decoders map[reflect.Type]any
+	// variants rejects treatments outside the allowed variants of their flag, if configured.
+	variants *variantGuard
+	// schemas holds the compiled schemas of the flags configured with WithFlagSchemas.
+	schemas map[string]compiledSchema
+	// parsed holds the JSON objects parsed from treatments and the verdicts of their schemas.
+	parsed   *parsedCache
+	metadata *metadataCache
+	events   chan openfeature.Event
+
+	mu sync.Mutex
+	// factory is the Split factory created by the provider itself, if any. It is destroyed on Shutdown.
+	factory *client.SplitFactory
+	// manager is the Split manager given with WithManager, used when factory is nil.
+	manager   *client.SplitManager
+	watcher   *localhostWatcher
+	snapshots *snapshotter
 }
 
-func NewProvider(splitClient *client.SplitClient) (*SplitProvider, error) {
+// NewProvider creates a SplitProvider backed by the given, already initialized, Split client.
+// Options are validated here; an invalid option makes NewProvider return an error.
+func NewProvider(splitClient *client.SplitClient, opts ...Option) (*SplitProvider, error) {
 	if splitClient == nil {
 		return nil, errNilSplitClient
 	}
-	return &SplitProvider{
-		client: splitClient,
-	}, nil
+	o, err := newProviderOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	if o.impressions.set() {
+		return nil, errImpressionsNeedFactory
+	}
+	if o.snapshotPath != "" {
+		return nil, errSnapshotNotSupported
+	}
+	if o.dedup != nil && o.manager == nil {
+		return nil, errDedupNeedsManager
+	}
+	if o.variants != nil && len(o.variants.FromDefinitions) > 0 && o.manager == nil {
+		return nil, errVariantsNeedManager
+	}
+	return newProvider(splitClient, o), nil
+}
+
+// newProvider creates a SplitProvider from validated options.
+func newProvider(splitClient *client.SplitClient, o providerOptions) *SplitProvider {
+	mapper := o.contextMapper
+	if mapper == nil {
+		mapper = DefaultContextMapper()
+	}
+	p := &SplitProvider{
+		recorder:  o.recorder,
+		fallbacks: o.fallbacks,
+		mapper:    mapper,
+		keyPolicy: o.keyPolicy,
+		deriver:   o.keyDeriver,
+		reporter:  o.trackReporter,
+		tracking:  o.contextTracking,
+		dedup:     newDedupCache(o.dedup),
+		parsed:    newParsedCache(),
+		metadata:  newMetadataCache(),
+		events:    make(chan openfeature.Event, eventBufferSize),
+		manager:   o.manager,
+	}
+	p.decoders = defaultDecoders()
+	for t, decoder := range o.decoders {
+		p.decoders[t] = decoder
+	}
+	p.variants = newVariantGuard(p, o.variants)
+	p.schemas = o.compiledSchemas
+	if o.contextValidation {
+		p.hooks = append(p.hooks, &contextValidationHook{
+			limits:    o.contextLimits,
+			mapper:    o.contextMapper,
+			keyPolicy: o.keyPolicy,
+			deriver:   o.keyDeriver,
+		})
+	}
+	if o.exposures != nil {
+		p.hooks = append(p.hooks, newExposureHook(p, *o.exposures))
+	}
+	p.splitClient.Store(splitClient)
+	return p
 }
 
 // NewProviderSimple creates a SplitProvider using the given API key and default config.
 // It is an alias for NewProviderWithAPIKey for backward compatibility.
-func NewProviderSimple(apiKey string) (*SplitProvider, error) {
-	return NewProviderWithAPIKey(apiKey)
+func NewProviderSimple(apiKey string, opts ...Option) (*SplitProvider, error) {
+	return NewProviderWithAPIKey(apiKey, opts...)
 }
 
 // NewProviderWithAPIKey creates a SplitProvider using the given API key and default config.
-// The client is created internally and blocks until ready (up to 10 seconds).
-// For more control, create a Split client yourself and use NewProvider.
-func NewProviderWithAPIKey(apiKey string) (*SplitProvider, error) {
-	cfg := conf.Default()
-	factory, err := client.NewSplitFactory(apiKey, cfg)
+// The client is created internally and blocks until ready (up to 10 seconds, see WithReadyTimeout).
+// If it is not ready in time and WithSnapshot points to a saved snapshot, the provider starts
+// serving the snapshot instead of failing. For more control, create a Split client yourself and use
+// NewProvider.
+func NewProviderWithAPIKey(apiKey string, opts ...Option) (*SplitProvider, error) {
+	return newProviderWithConfig(apiKey, conf.Default(), opts)
+}
+
+// newProviderWithConfig creates a Split factory from apiKey and cfg (adjusted by the options),
+// waits for its client to be ready and wraps it in a SplitProvider.
+func newProviderWithConfig(apiKey string, cfg *conf.SplitSdkConfig, opts []Option) (*SplitProvider, error) {
+	o, err := newProviderOptions(opts)
 	if err != nil {
 		return nil, err
 	}
-	splitClient := factory.Client()
-	err = splitClient.BlockUntilReady(10)
+	o.applySDKConfig(cfg)
+	factory, err := client.NewSplitFactory(apiKey, cfg)
 	if err != nil {
 		return nil, err
 	}
-	return NewProvider(splitClient)
+	splitClient := factory.Client()
+	var stale *flagSnapshot
+	if err := splitClient.BlockUntilReady(o.readyTimeoutSeconds()); err != nil {
+		if o.snapshotPath == "" {
+			return nil, err
+		}
+		// Without a usable snapshot the provider cannot start any better than without one.
+		if stale, _ = loadSnapshot(o.snapshotPath); stale == nil {
+			return nil, err
+		}
+	}
+	p := newProvider(splitClient, o)
+	p.factory = factory
+	if apiKey != localhostAPIKey && o.impressions.disablesFlags() {
+		quiet, err := newQuietClient(apiKey, cfg, factory.Manager(), o.impressions, o.readyTimeoutSeconds())
+		if err != nil {
+			factory.Destroy()
+			return nil, err
+		}
+		p.quiet.Store(quiet)
+	}
+	if o.snapshotPath != "" {
+		p.startSnapshots(o.snapshotPath, o.snapshotInterval, stale)
+	}
+	return p, nil
 }
 
 func (p *SplitProvider) Metadata() openfeature.Metadata {
 	return openfeature.Metadata{
-		Name: "Split",
+		Name: providerName,
 	}
 }
 
+// currentClient returns the Split client serving evaluations.
+func (p *SplitProvider) currentClient() *client.SplitClient {
+	return p.splitClient.Load()
+}
+
+// currentFactory returns the Split factory created by the provider, or nil.
+func (p *SplitProvider) currentFactory() *client.SplitFactory {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.factory
+}
+
 func (p *SplitProvider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, flatCtx openfeature.FlattenedContext) openfeature.BoolResolutionDetail {
-	if noTargetingKey(flatCtx) {
-		return openfeature.BoolResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: detailTargetingKeyMissing(),
-		}
-	}
-	treatment, config := p.evaluateTreatmentWithConfig(flag, flatCtx)
-	if noTreatment(treatment) {
-		return openfeature.BoolResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: detailFlagNotFound(treatment),
-		}
-	}
-	var value bool
-	switch treatment {
-	case "true", "on":
-		value = true
-	case "false", "off":
-		value = false
-	default:
-		return openfeature.BoolResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: detailParseError(treatment),
-		}
-	}
-	return openfeature.BoolResolutionDetail{
-		Value:                    value,
-		ProviderResolutionDetail: detailSuccess(treatment, config),
-	}
+	detail := resolve(ctx, p, openfeature.Boolean, flag, defaultValue, flatCtx, booleanDecoder)
+	return openfeature.BoolResolutionDetail{Value: detail.Value, ProviderResolutionDetail: detail.ProviderResolutionDetail}
 }
 
 func (p *SplitProvider) StringEvaluation(ctx context.Context, flag string, defaultValue string, flatCtx openfeature.FlattenedContext) openfeature.StringResolutionDetail {
-	if noTargetingKey(flatCtx) {
-		return openfeature.StringResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: detailTargetingKeyMissing(),
-		}
-	}
-	treatment, config := p.evaluateTreatmentWithConfig(flag, flatCtx)
-	if noTreatment(treatment) {
-		return openfeature.StringResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: detailFlagNotFound(treatment),
-		}
-	}
-	return openfeature.StringResolutionDetail{
-		Value:                    treatment,
-		ProviderResolutionDetail: detailSuccess(treatment, config),
-	}
+	detail := resolve(ctx, p, openfeature.String, flag, defaultValue, flatCtx, stringDecoder)
+	return openfeature.StringResolutionDetail{Value: detail.Value, ProviderResolutionDetail: detail.ProviderResolutionDetail}
 }
 
 func (p *SplitProvider) FloatEvaluation(ctx context.Context, flag string, defaultValue float64, flatCtx openfeature.FlattenedContext) openfeature.FloatResolutionDetail {
-	if noTargetingKey(flatCtx) {
-		return openfeature.FloatResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: detailTargetingKeyMissing(),
-		}
-	}
-	treatment, config := p.evaluateTreatmentWithConfig(flag, flatCtx)
-	if noTreatment(treatment) {
-		return openfeature.FloatResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: detailFlagNotFound(treatment),
-		}
-	}
-	floatEvaluated, parseErr := strconv.ParseFloat(treatment, 64)
-	if parseErr != nil {
-		return openfeature.FloatResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: detailParseError(treatment),
-		}
-	}
-	return openfeature.FloatResolutionDetail{
-		Value:                    floatEvaluated,
-		ProviderResolutionDetail: detailSuccess(treatment, config),
-	}
+	detail := resolve(ctx, p, openfeature.Float, flag, defaultValue, flatCtx, floatDecoder)
+	return openfeature.FloatResolutionDetail{Value: detail.Value, ProviderResolutionDetail: detail.ProviderResolutionDetail}
 }
 
 func (p *SplitProvider) IntEvaluation(ctx context.Context, flag string, defaultValue int64, flatCtx openfeature.FlattenedContext) openfeature.IntResolutionDetail {
-	if noTargetingKey(flatCtx) {
-		return openfeature.IntResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: detailTargetingKeyMissing(),
-		}
-	}
-	treatment, config := p.evaluateTreatmentWithConfig(flag, flatCtx)
-	if noTreatment(treatment) {
-		return openfeature.IntResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: detailFlagNotFound(treatment),
-		}
-	}
-	intEvaluated, parseErr := strconv.ParseInt(treatment, 10, 64)
-	if parseErr != nil {
-		return openfeature.IntResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: detailParseError(treatment),
-		}
-	}
-	return openfeature.IntResolutionDetail{
-		Value:                    intEvaluated,
-		ProviderResolutionDetail: detailSuccess(treatment, config),
-	}
+	detail := resolve(ctx, p, openfeature.Int, flag, defaultValue, flatCtx, intDecoder)
+	return openfeature.IntResolutionDetail{Value: detail.Value, ProviderResolutionDetail: detail.ProviderResolutionDetail}
 }
 
 func (p *SplitProvider) ObjectEvaluation(ctx context.Context, flag string, defaultValue interface{}, flatCtx openfeature.FlattenedContext) openfeature.InterfaceResolutionDetail {
-	if noTargetingKey(flatCtx) {
-		return openfeature.InterfaceResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: detailTargetingKeyMissing(),
-		}
-	}
-	treatment, config := p.evaluateTreatmentWithConfig(flag, flatCtx)
-	if noTreatment(treatment) {
-		return openfeature.InterfaceResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: detailFlagNotFound(treatment),
-		}
-	}
-	var data map[string]interface{}
-	parseErr := json.Unmarshal([]byte(treatment), &data)
-	if parseErr != nil {
-		return openfeature.InterfaceResolutionDetail{
-			Value:                    defaultValue,
-			ProviderResolutionDetail: detailParseError(treatment),
-		}
-	}
-	return openfeature.InterfaceResolutionDetail{
-		Value:                    data,
-		ProviderResolutionDetail: detailSuccess(treatment, config),
-	}
+	detail := resolve(ctx, p, openfeature.Object, flag, defaultValue, flatCtx, objectDecoder)
+	return openfeature.InterfaceResolutionDetail{Value: detail.Value, ProviderResolutionDetail: detail.ProviderResolutionDetail}
 }
 
-// Hooks returns no provider-specific hooks.
+// Hooks returns the provider hooks: the context validation hook when enabled with
+// WithContextValidation or WithContextLimits, which rejects evaluation contexts Split cannot evaluate
+// (see ContextLimits) before the provider is called, and the exposure hook of WithExposureTracking.
 func (p *SplitProvider) Hooks() []openfeature.Hook {
-	return nil
+	return p.hooks
 }
 
 // Track sends a tracking event to Split. It implements the openfeature.Tracker interface.
-// Key is taken from evaluationContext.TargetingKey(); traffic type from evaluation context attribute "trafficType".
-// If either is missing or empty, Track returns without sending (same as key requirement for evaluations).
+// Key is mapped from the evaluation context like for evaluations (by default its targeting key,
+// subject to the TargetingKeyPolicy); traffic type from evaluation context attribute "trafficType".
+// If either is missing or empty, or the event name is not one Split accepts, the event is not sent.
+// Event attributes, along with the context attributes selected with WithContextTrackingProperties,
+// are normalized with NormalizeTrackingProperties and events larger than Split's
+// size limit are not sent either; see WithTrackReporter to find out about both.
 func (p *SplitProvider) Track(ctx context.Context, trackingEventName string, evaluationContext openfeature.EvaluationContext, details openfeature.TrackingEventDetails) {
-	key := evaluationContext.TargetingKey()
-	if key == "" {
+	report := TrackReport{EventName: trackingEventName}
+	if p.reporter != nil {
+		defer func() {
+			if report.Err != nil || len(report.Dropped) > 0 {
+				p.reporter(report)
+			}
+		}()
+	}
+	in, _, ok := p.mapContext(flattenEvaluationContext(evaluationContext))
+	if !ok {
+		report.Err = fmt.Errorf("%w: no key in the evaluation context", ErrTrackingEventRejected)
+		return
+	}
+	trafficType, _ := evaluationContext.Attribute(trafficTypeAttribute).(string)
+	if trafficType == "" {
+		report.Err = fmt.Errorf("%w: no trafficType in the evaluation context", ErrTrackingEventRejected)
 		return
 	}
-	trafficType := evaluationContext.Attribute("trafficType")
-	if trafficType == nil {
+	if err := ValidateTrackingEventName(trackingEventName); err != nil {
+		report.Err = err
 		return
 	}
-	trafficTypeStr, ok := trafficType.(string)
-	if !ok || trafficTypeStr == "" {
+	properties, dropped := NormalizeTrackingProperties(p.tracking.merge(details.Attributes(), in.attrs))
+	report.Dropped = dropped
+	if size := trackPropertiesSize(properties); size > client.MaxEventLength {
+		report.Err = fmt.Errorf("%w: event size %d exceeds %d bytes", ErrTrackingEventRejected, size, client.MaxEventLength)
 		return
 	}
-	value := details.Value()
-	properties := stringMapFromAttributes(details.Attributes())
-	_ = p.client.Track(key, trafficTypeStr, trackingEventName, value, properties)
+	report.Err = p.currentClient().Track(in.key, trafficType, trackingEventName, details.Value(), properties)
 }
 
-// stringMapFromAttributes converts map[string]any to map[string]interface{} for the Split SDK.
-// Returns nil if attrs is nil or empty so the Split client receives nil for optional properties.
-func stringMapFromAttributes(attrs map[string]any) map[string]interface{} {
-	if attrs == nil || len(attrs) == 0 {
-		return nil
+// *** Helpers ***
+
+// splitInput is what an evaluation context maps to for the Split SDK.
+type splitInput struct {
+	key   string
+	attrs map[string]interface{}
+	// derivedKey is the source of key when it was derived (see WithDerivedKeys), otherwise empty.
+	derivedKey string
+}
+
+// mapContext applies the targeting key policy, the context mapper and the key deriver to flatCtx,
+// leaving out the EvaluationPropertiesAttribute attribute. When the context cannot be evaluated ok
+// is false and failure holds the resolution detail to return.
+func (p *SplitProvider) mapContext(flatCtx openfeature.FlattenedContext) (in splitInput, failure openfeature.ProviderResolutionDetail, ok bool) {
+	flatCtx, err := p.keyPolicy.normalize(withoutEvaluationProperties(flatCtx))
+	if err != nil {
+		return splitInput{}, detailInvalidContext(err), false
 	}
-	out := make(map[string]interface{}, len(attrs))
-	for k, v := range attrs {
-		out[k] = v
+	in.key, in.attrs = p.mapper.Map(flatCtx)
+	if in.key == "" {
+		in.key, in.derivedKey = p.deriver.derive(flatCtx)
 	}
-	return out
+	if in.key == "" {
+		return splitInput{}, detailTargetingKeyMissing(), false
+	}
+	return in, failure, true
 }
 
-// *** Helpers ***
-
 // splitKeyAndAttributes returns the targeting key and attributes from a flattened evaluation context.
-// Key is taken from flatCtx[TargetingKey]; all other entries become attributes for Split.
+// Key is taken from flatCtx[TargetingKey], which TargetingKeyPolicy has already made a string;
+// all other entries become attributes for Split.
+// When there are no other entries attrs is nil, so the common key-only context allocates nothing.
 func splitKeyAndAttributes(flatCtx openfeature.FlattenedContext) (key string, attrs map[string]interface{}) {
-	attrs = make(map[string]interface{})
+	v, hasKey := flatCtx[openfeature.TargetingKey]
+	key, _ = v.(string)
+	n := len(flatCtx)
+	if hasKey {
+		n--
+	}
+	if n == 0 {
+		return key, nil
+	}
+	attrs = make(map[string]interface{}, n)
 	for k, v := range flatCtx {
 		if k != openfeature.TargetingKey {
 			attrs[k] = v
 		}
 	}
-	if v := flatCtx[openfeature.TargetingKey]; v != nil {
-		if s, ok := v.(string); ok {
-			key = s
-		} else {
-			key = fmt.Sprint(v)
-		}
-	}
 	return key, attrs
 }
 
-// evaluateTreatmentWithConfig returns treatment and optional config from Split.
-// Key and attributes are derived from flatCtx (targetingKey + rest as attributes).
-func (p *SplitProvider) evaluateTreatmentWithConfig(flag string, flatCtx openfeature.FlattenedContext) (treatment string, config *string) {
-	key, attrs := splitKeyAndAttributes(flatCtx)
-	result := p.client.TreatmentWithConfig(key, flag, attrs)
-	return result.Treatment, result.Config
+// flattenEvaluationContext builds the FlattenedContext OpenFeature would pass to evaluations.
+func flattenEvaluationContext(evaluationContext openfeature.EvaluationContext) openfeature.FlattenedContext {
+	flatCtx := openfeature.FlattenedContext(evaluationContext.Attributes())
+	if flatCtx == nil {
+		flatCtx = openfeature.FlattenedContext{}
+	}
+	if key := evaluationContext.TargetingKey(); key != "" {
+		flatCtx[openfeature.TargetingKey] = key
+	}
+	return flatCtx
+}
+
+// evaluateTreatmentWithConfig returns treatment and optional config from Split for the key and
+// attributes mapped from the evaluation context (see ContextMapper and WithDerivedKeys).
+// When trace is not nil it captures the impression generated by the call, which carries the
+// evaluation properties in options, if any (see WithEvaluationProperties).
+// If ctx carries an evaluation cache (see WithEvaluationCache), a cached result is returned instead
+// of calling Split again, and so is a recent result when WithEvaluationDedup is set. Flags with
+// impressions disabled (see WithImpressionsDisabled) are evaluated with the provider's quiet client;
+// results reused from a cache generate no impression either way.
+// A "control" result is replaced by the configured fallback, if any.
+// While the provider serves a snapshot (see WithSnapshot), flags in it are resolved without Split.
+func (p *SplitProvider) evaluateTreatmentWithConfig(ctx context.Context, flag string, in splitInput, options *dtos.EvaluationOptions, trace *evaluationTrace) splitResult {
+	result := p.treatmentWithConfig(ctx, flag, in.key, in.attrs, options, trace)
+	result.derivedKey = in.derivedKey
+	return result
+}
+
+// treatmentWithConfig resolves flag for key and attrs as described for evaluateTreatmentWithConfig.
+func (p *SplitProvider) treatmentWithConfig(ctx context.Context, flag string, key string, attrs map[string]interface{}, options *dtos.EvaluationOptions, trace *evaluationTrace) splitResult {
+	if snapshot := p.stale.Load(); snapshot != nil {
+		if result, ok := snapshot.results[flag]; ok {
+			return result
+		}
+	}
+	cache := evaluationCacheFrom(ctx)
+	var cacheKey evaluationCacheKey
+	var generation uint64
+	var changeNumber int64
+	if cache != nil || p.dedup != nil {
+		cacheKey = evaluationCacheKey{flag: flag, key: key, attrs: canonicalAttributes(attrs)}
+	}
+	if cache != nil {
+		if result, ok := cache.get(cacheKey); ok {
+			result.cached = true
+			return p.withFallback(flag, result)
+		}
+	}
+	if p.dedup != nil {
+		// Read before evaluating, so a change made meanwhile makes the stored result miss.
+		changeNumber = p.changeNumber(flag)
+		result, gen, ok := p.dedup.get(cacheKey, changeNumber)
+		if ok {
+			if cache != nil {
+				cache.put(cacheKey, result)
+			}
+			result.cached = true
+			return p.withFallback(flag, result)
+		}
+		generation = gen
+	}
+	splitClient := p.currentClient()
+	if quiet := p.quiet.Load(); quiet != nil {
+		if c := quiet.clientFor(flag); c != nil {
+			splitClient = c
+		}
+	}
+	trace.expect(key)
+	var treatmentResult client.TreatmentResult
+	if options != nil {
+		treatmentResult = splitClient.TreatmentWithConfig(key, flag, attrs, splitClient.WithEvaluationOptions(options))
+	} else {
+		treatmentResult = splitClient.TreatmentWithConfig(key, flag, attrs)
+	}
+	trace.done()
+	result := splitResult{
+		treatment: treatmentResult.Treatment,
+		config:    treatmentResult.Config,
+		metadata:  p.metadata.forConfig(treatmentResult.Config),
+	}
+	if cache != nil {
+		cache.put(cacheKey, result)
+	}
+	if p.dedup != nil {
+		p.dedup.put(cacheKey, result, changeNumber, generation)
+	}
+	return p.withFallback(flag, result)
 }
 
 func flagMetadataWithConfig(config string) openfeature.FlagMetadata {
 	return openfeature.FlagMetadata{flagMetadataConfigKey: config}
 }
 
-func noTargetingKey(flatCtx openfeature.FlattenedContext) bool {
-	v, ok := flatCtx[openfeature.TargetingKey]
-	if !ok {
-		return true
+// parseBooleanTreatment maps the treatments "true"/"on" and "false"/"off" to booleans.
+func parseBooleanTreatment(treatment string) (bool, error) {
+	switch treatment {
+	case "true", "on":
+		return true, nil
+	case "false", "off":
+		return false, nil
+	}
+	return false, errors.New("expected true, on, false or off")
+}
+
+func parseFloatTreatment(treatment string) (float64, error) {
+	return strconv.ParseFloat(treatment, 64)
+}
+
+func parseIntTreatment(treatment string) (int64, error) {
+	return strconv.ParseInt(treatment, 10, 64)
+}
+
+// parseObjectTreatment decodes a treatment holding a JSON object.
+func parseObjectTreatment(treatment string) (map[string]interface{}, error) {
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(treatment), &data); err != nil {
+		return nil, err
 	}
-	s, _ := v.(string)
-	return s == ""
+	return data, nil
+}
+
+// parseTreatment checks that treatment parses as flagType with the same rules as the *Evaluation methods.
+func parseTreatment(flagType openfeature.Type, treatment string) error {
+	var err error
+	switch flagType {
+	case openfeature.Boolean:
+		_, err = parseBooleanTreatment(treatment)
+	case openfeature.Int:
+		_, err = parseIntTreatment(treatment)
+	case openfeature.Float:
+		_, err = parseFloatTreatment(treatment)
+	case openfeature.Object:
+		_, err = parseObjectTreatment(treatment)
+	case openfeature.String:
+	default:
+		err = fmt.Errorf("unsupported flag type %d", flagType)
+	}
+	return err
 }
 
 func noTreatment(treatment string) bool {
-	return treatment == "" || treatment == "control"
+	return treatment == "" || treatment == controlTreatment
 }
 
 func detailFlagNotFound(variant string) openfeature.ProviderResolutionDetail {
@@ -293,6 +481,17 @@ func detailParseError(variant string) openfeature.ProviderResolutionDetail {
 	}
 }
 
+func detailInvalidContext(err error) openfeature.ProviderResolutionDetail {
+	var resErr openfeature.ResolutionError
+	if !errors.As(err, &resErr) {
+		resErr = openfeature.NewInvalidContextResolutionError(err.Error())
+	}
+	return openfeature.ProviderResolutionDetail{
+		ResolutionError: resErr,
+		Reason:          openfeature.ErrorReason,
+	}
+}
+
 func detailTargetingKeyMissing() openfeature.ProviderResolutionDetail {
 	return openfeature.ProviderResolutionDetail{
 		ResolutionError: openfeature.NewTargetingKeyMissingResolutionError("targeting key is required and missing"),
@@ -310,14 +509,23 @@ func providerResolutionDetailError(err openfeature.ResolutionError, reason openf
 	}
 }
 
-func detailSuccess(variant string, config *string) openfeature.ProviderResolutionDetail {
-	meta := openfeature.FlagMetadata(nil)
-	if config != nil && *config != "" {
-		meta = openfeature.FlagMetadata{flagMetadataConfigKey: *config}
+func detailSuccess(result splitResult) openfeature.ProviderResolutionDetail {
+	reason := openfeature.TargetingMatchReason
+	switch {
+	case result.stale:
+		reason = StaleReason
+	case result.fallback:
+		reason = openfeature.DefaultReason
+	case result.cached:
+		reason = openfeature.CachedReason
+	}
+	metadata := result.metadata
+	if result.derivedKey != "" {
+		metadata = withDerivedKey(metadata, result.derivedKey)
 	}
 	return openfeature.ProviderResolutionDetail{
-		Reason:       openfeature.TargetingMatchReason,
-		Variant:      variant,
-		FlagMetadata: meta,
+		Reason:       reason,
+		Variant:      result.treatment,
+		FlagMetadata: metadata,
 	}
 }
@@ -0,0 +1,190 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/splitio/go-client/v6/splitio/client"
+	"github.com/splitio/go-client/v6/splitio/conf"
+	"github.com/splitio/go-toolkit/v5/logging"
+)
+
+func benchmarkProvider(b *testing.B) *SplitProvider {
+	b.Helper()
+	cfg := conf.Default()
+	cfg.SplitFile = "./split.yaml"
+	cfg.LoggerConfig.LogLevel = logging.LevelNone
+	factory, err := client.NewSplitFactory("localhost", cfg)
+	if err != nil {
+		b.Fatal("Error creating split factory")
+	}
+	splitClient := factory.Client()
+	if err := splitClient.BlockUntilReady(10); err != nil {
+		b.Fatal("Split SDK timeout error")
+	}
+	provider, err := NewProvider(splitClient)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return provider
+}
+
+func keyOnlyContext() openfeature.FlattenedContext {
+	return openfeature.FlattenedContext{openfeature.TargetingKey: "key"}
+}
+
+func BenchmarkBooleanEvaluation(b *testing.B) {
+	provider := benchmarkProvider(b)
+	ctx, flatCtx := context.Background(), keyOnlyContext()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		provider.BooleanEvaluation(ctx, "my_feature", false, flatCtx)
+	}
+}
+
+func BenchmarkBooleanEvaluationWithAttributes(b *testing.B) {
+	provider := benchmarkProvider(b)
+	ctx := context.Background()
+	flatCtx := openfeature.FlattenedContext{openfeature.TargetingKey: "key", "plan": "pro", "region": "eu"}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		provider.BooleanEvaluation(ctx, "my_feature", false, flatCtx)
+	}
+}
+
+func BenchmarkStringEvaluation(b *testing.B) {
+	provider := benchmarkProvider(b)
+	ctx, flatCtx := context.Background(), keyOnlyContext()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		provider.StringEvaluation(ctx, "some_other_feature", "", flatCtx)
+	}
+}
+
+func BenchmarkIntEvaluation(b *testing.B) {
+	provider := benchmarkProvider(b)
+	ctx, flatCtx := context.Background(), keyOnlyContext()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		provider.IntEvaluation(ctx, "int_feature", 0, flatCtx)
+	}
+}
+
+func BenchmarkFloatEvaluation(b *testing.B) {
+	provider := benchmarkProvider(b)
+	ctx, flatCtx := context.Background(), keyOnlyContext()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		provider.FloatEvaluation(ctx, "float_feature", 0, flatCtx)
+	}
+}
+
+func BenchmarkObjectEvaluation(b *testing.B) {
+	provider := benchmarkProvider(b)
+	ctx, flatCtx := context.Background(), keyOnlyContext()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		provider.ObjectEvaluation(ctx, "obj_feature", nil, flatCtx)
+	}
+}
+
+// largeObjectTreatment is a config-heavy object treatment, the case the parsed value cache targets.
+const largeObjectTreatment = `{"layout":"grid","columns":4,"theme":{"primary":"#0055ff","secondary":"#ffffff","fonts":["Inter","Roboto","Arial"]},` +
+	`"sections":[{"id":"hero","visible":true,"items":[1,2,3]},{"id":"recommendations","visible":true,"items":[4,5,6,7]},` +
+	`{"id":"footer","visible":false,"items":[]}],"limits":{"cart":50,"wishlist":200,"compare":4},"copy":{"title":"Welcome back",` +
+	`"subtitle":"Pick up where you left off","cta":"Continue shopping"}}`
+
+func benchmarkLargeObjectEvaluation(b *testing.B, provider *SplitProvider, cached bool) {
+	defer provider.Shutdown()
+	if !cached {
+		provider.parsed = nil
+	}
+	ctx, flatCtx := context.Background(), keyOnlyContext()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		provider.ObjectEvaluation(ctx, "layout", nil, flatCtx)
+	}
+}
+
+func largeObjectLocalhostProvider(b *testing.B) *SplitProvider {
+	provider, err := NewLocalhostProviderFromDefinitions([]LocalFlag{{Name: "layout", Treatment: largeObjectTreatment}}, quietSDK())
+	if err != nil {
+		b.Fatal(err)
+	}
+	return provider
+}
+
+// largeObjectRedisProvider serves the large object treatment from Redis, where every evaluation
+// reads the flag definition from the server.
+func largeObjectRedisProvider(b *testing.B) *SplitProvider {
+	server := miniredis.RunT(b)
+	synchronize(b, server, "bench")
+	layout, err := json.Marshal(map[string]any{"name": "layout", "trafficTypeName": "user", "status": "ACTIVE",
+		"killed": false, "defaultTreatment": largeObjectTreatment, "changeNumber": 1, "algo": 2, "seed": 1,
+		"trafficAllocation": 100, "trafficAllocationSeed": 1, "conditions": []any{}})
+	if err != nil {
+		b.Fatal(err)
+	}
+	if err := server.Set("bench.SPLITIO.split.layout", string(layout)); err != nil {
+		b.Fatal(err)
+	}
+	provider, err := NewRedisConsumerProvider(context.Background(), "api-key", redisConfigFor(b, server, "bench"), quietSDK())
+	if err != nil {
+		b.Fatal(err)
+	}
+	return provider
+}
+
+func BenchmarkObjectEvaluationLarge(b *testing.B) {
+	benchmarkLargeObjectEvaluation(b, largeObjectLocalhostProvider(b), true)
+}
+
+// BenchmarkObjectEvaluationLargeUncached parses the treatment on every evaluation, as a baseline
+// for BenchmarkObjectEvaluationLarge.
+func BenchmarkObjectEvaluationLargeUncached(b *testing.B) {
+	benchmarkLargeObjectEvaluation(b, largeObjectLocalhostProvider(b), false)
+}
+
+func BenchmarkObjectEvaluationLargeRedis(b *testing.B) {
+	benchmarkLargeObjectEvaluation(b, largeObjectRedisProvider(b), true)
+}
+
+// BenchmarkObjectEvaluationLargeRedisUncached is the baseline for BenchmarkObjectEvaluationLargeRedis.
+func BenchmarkObjectEvaluationLargeRedisUncached(b *testing.B) {
+	benchmarkLargeObjectEvaluation(b, largeObjectRedisProvider(b), false)
+}
+
+// TestEvaluationPathAllocations guards the provider's own share of the evaluation path: a key-only
+// context and an already seen treatment config must not allocate. Allocations made inside the Split
+// SDK are covered by the benchmarks above.
+func TestEvaluationPathAllocations(t *testing.T) {
+	flatCtx := keyOnlyContext()
+	if allocs := testing.AllocsPerRun(100, func() { splitKeyAndAttributes(flatCtx) }); allocs != 0 {
+		t.Errorf("splitKeyAndAttributes allocated %v times for a key-only context", allocs)
+	}
+
+	cache := newMetadataCache()
+	config := `{"desc": "config"}`
+	first := cache.forConfig(&config)
+	if allocs := testing.AllocsPerRun(100, func() { cache.forConfig(&config) }); allocs != 0 {
+		t.Errorf("forConfig allocated %v times for a known config", allocs)
+	}
+	if cache.forConfig(nil) != nil {
+		t.Error("Expected nil metadata without config")
+	}
+
+	result := splitResult{treatment: "on", config: &config, metadata: first}
+	if allocs := testing.AllocsPerRun(100, func() { detailSuccess(result) }); allocs != 0 {
+		t.Errorf("detailSuccess allocated %v times", allocs)
+	}
+}
@@ -12,7 +12,7 @@ import (
 	"github.com/splitio/go-toolkit/v5/logging"
 )
 
-func create(t *testing.T) *openfeature.Client {
+func create(t *testing.T, opts ...Option) *openfeature.Client {
 	cfg := conf.Default()
 	cfg.SplitFile = "./split.yaml"
 	cfg.LoggerConfig.LogLevel = logging.LevelNone
@@ -27,7 +27,7 @@ func create(t *testing.T) *openfeature.Client {
 		// error timeout
 		t.Error("Split sdk timeout error")
 	}
-	provider, err := NewProvider(splitClient)
+	provider, err := NewProvider(splitClient, opts...)
 	if err != nil {
 		t.Error(err)
 	}
@@ -0,0 +1,79 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/splitio/go-client/v6/splitio/client"
+	"github.com/splitio/go-client/v6/splitio/conf"
+	commonsconf "github.com/splitio/go-split-commons/v9/conf"
+)
+
+// redisSyncPollInterval is how often NewRedisConsumerProvider checks Redis for flag definitions.
+const redisSyncPollInterval = 100 * time.Millisecond
+
+// ErrRedisNotSynchronized is returned by NewRedisConsumerProvider when Redis holds no flag
+// definitions before the deadline, usually because the Split synchronizer is not running or uses a
+// different Redis prefix or database.
+var ErrRedisNotSynchronized = errors.New("no Split flag definitions found in Redis")
+
+// NewRedisConsumerProvider creates a SplitProvider in Split consumer mode: the SDK evaluates the flag
+// definitions the Split synchronizer keeps in Redis (at redisCfg, including its Prefix) and writes
+// impressions and events back to Redis, without contacting Split itself.
+//
+// Consumer mode has no initial download, so instead of waiting for SDK readiness the constructor
+// checks that Redis is reachable and waits until it holds flag definitions. The wait ends when ctx
+// is done or the ready timeout (see WithReadyTimeout) expires, whichever comes first, with an error
+// wrapping ErrRedisNotSynchronized. Environments without any flag therefore cannot be consumed.
+func NewRedisConsumerProvider(ctx context.Context, apiKey string, redisCfg commonsconf.RedisConfig, opts ...Option) (*SplitProvider, error) {
+	o, err := newProviderOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	if o.snapshotPath != "" {
+		return nil, errSnapshotNotSupported
+	}
+	cfg := conf.Default()
+	cfg.OperationMode = conf.RedisConsumer
+	cfg.Redis = redisCfg
+	o.applySDKConfig(cfg)
+	factory, err := client.NewSplitFactory(apiKey, cfg)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, o.readyTimeout)
+	defer cancel()
+	if err := waitForRedisDefinitions(ctx, factory.Manager()); err != nil {
+		factory.Destroy()
+		return nil, err
+	}
+	p := newProvider(factory.Client(), o)
+	p.factory = factory
+	if o.impressions.disablesFlags() {
+		quiet, err := newQuietClient(apiKey, cfg, factory.Manager(), o.impressions, o.readyTimeoutSeconds())
+		if err != nil {
+			factory.Destroy()
+			return nil, err
+		}
+		p.quiet.Store(quiet)
+	}
+	return p, nil
+}
+
+// waitForRedisDefinitions waits until the manager sees at least one flag definition in Redis.
+func waitForRedisDefinitions(ctx context.Context, manager *client.SplitManager) error {
+	ticker := time.NewTicker(redisSyncPollInterval)
+	defer ticker.Stop()
+	for {
+		if len(manager.SplitNames()) > 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ErrRedisNotSynchronized, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
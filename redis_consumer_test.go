@@ -0,0 +1,90 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/open-feature/go-sdk/openfeature"
+	commonsconf "github.com/splitio/go-split-commons/v9/conf"
+)
+
+const redisCheckoutFlag = `{"name": "checkout", "trafficTypeName": "user", "status": "ACTIVE", "killed": false,
+ "defaultTreatment": "v1", "changeNumber": 1, "algo": 2, "seed": 1, "trafficAllocation": 100,
+ "trafficAllocationSeed": 1, "conditions": [], "configurations": {"v1": "{\"color\":\"grey\"}"}}`
+
+func redisConfigFor(t testing.TB, server *miniredis.Miniredis, prefix string) commonsconf.RedisConfig {
+	t.Helper()
+	port, err := strconv.Atoi(server.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return commonsconf.RedisConfig{Host: server.Host(), Port: port, Prefix: prefix}
+}
+
+// synchronize stores flag definitions as the Split synchronizer would.
+func synchronize(t testing.TB, server *miniredis.Miniredis, prefix string) {
+	t.Helper()
+	if err := server.Set(prefix+".SPLITIO.split.checkout", redisCheckoutFlag); err != nil {
+		t.Error(err)
+	}
+	if err := server.Set(prefix+".SPLITIO.splits.till", "1"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNewRedisConsumerProvider(t *testing.T) {
+	server := miniredis.RunT(t)
+	synchronize(t, server, "myapp")
+	provider, err := NewRedisConsumerProvider(context.Background(), "api-key", redisConfigFor(t, server, "myapp"), quietSDK())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+
+	result := provider.StringEvaluation(context.Background(), "checkout", "default", openfeature.FlattenedContext{openfeature.TargetingKey: "key"})
+	if result.Value != "v1" || result.Reason != openfeature.TargetingMatchReason {
+		t.Errorf("Expected v1 from Redis, got %+v", result)
+	}
+	if result.FlagMetadata[flagMetadataConfigKey] != `{"color":"grey"}` {
+		t.Errorf("Expected the treatment config, got %v", result.FlagMetadata)
+	}
+}
+
+func TestNewRedisConsumerProvider_WaitsForSynchronizer(t *testing.T) {
+	server := miniredis.RunT(t)
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		synchronize(t, server, "myapp")
+	}()
+	provider, err := NewRedisConsumerProvider(context.Background(), "api-key", redisConfigFor(t, server, "myapp"), quietSDK())
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider.Shutdown()
+}
+
+func TestNewRedisConsumerProvider_PrefixMismatch(t *testing.T) {
+	server := miniredis.RunT(t)
+	synchronize(t, server, "other")
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	_, err := NewRedisConsumerProvider(ctx, "api-key", redisConfigFor(t, server, "myapp"), quietSDK())
+	if !errors.Is(err, ErrRedisNotSynchronized) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected ErrRedisNotSynchronized after the deadline, got %v", err)
+	}
+}
+
+func TestNewRedisConsumerProvider_Unreachable(t *testing.T) {
+	server := miniredis.RunT(t)
+	cfg := redisConfigFor(t, server, "myapp")
+	server.Close()
+
+	if _, err := NewRedisConsumerProvider(context.Background(), "api-key", cfg, quietSDK()); err == nil {
+		t.Error("Expected an error when Redis is unreachable")
+	}
+}
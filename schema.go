@@ -0,0 +1,169 @@
+package split_openfeature_provider_go
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// FlagSchema holds the JSON Schemas the payloads of a flag must satisfy, as JSON documents. Schemas
+// without "$schema" are read as draft 2020-12.
+type FlagSchema struct {
+	// Treatment is the schema of the flag's treatments parsed as JSON, for flags evaluated as
+	// objects. Empty to leave treatments unchecked.
+	Treatment string
+	// Config is the schema of the flag's treatment configs. Treatments without config are not
+	// checked. Empty to leave configs unchecked.
+	Config string
+}
+
+// WithFlagSchemas validates the treatments and treatment configs of the given flags against JSON
+// Schemas, keyed by flag name. Evaluations serving a payload that does not satisfy its schema
+// return the default value with PARSE_ERROR describing the violations. Schemas are compiled once,
+// when the provider is created; invalid schemas make the constructor fail. Use
+// SplitProvider.ValidateFlagSchemas to check every treatment known to Split at startup.
+func WithFlagSchemas(schemas map[string]FlagSchema) Option {
+	return func(o *providerOptions) {
+		o.schemas = make(map[string]FlagSchema, len(schemas))
+		for flag, schema := range schemas {
+			o.schemas[flag] = schema
+		}
+	}
+}
+
+// compiledSchema is a compiled FlagSchema; nil fields leave their payload unchecked.
+type compiledSchema struct {
+	treatment *jsonschema.Schema
+	config    *jsonschema.Schema
+}
+
+// compileSchemas compiles every schema, or returns an error naming the first invalid one.
+func compileSchemas(schemas map[string]FlagSchema) (map[string]compiledSchema, error) {
+	if len(schemas) == 0 {
+		return nil, nil
+	}
+	compiled := make(map[string]compiledSchema, len(schemas))
+	for flag, schema := range schemas {
+		var c compiledSchema
+		var err error
+		if c.treatment, err = compileSchema(flag, "treatment", schema.Treatment); err != nil {
+			return nil, err
+		}
+		if c.config, err = compileSchema(flag, "config", schema.Config); err != nil {
+			return nil, err
+		}
+		compiled[flag] = c
+	}
+	return compiled, nil
+}
+
+func compileSchema(flag, payload, schema string) (*jsonschema.Schema, error) {
+	if schema == "" {
+		return nil, nil
+	}
+	doc, err := jsonschema.UnmarshalJSON(strings.NewReader(schema))
+	if err != nil {
+		return nil, fmt.Errorf("%s schema of flag %s is not valid JSON: %w", payload, flag, err)
+	}
+	url := fmt.Sprintf("urn:split:%s:%s", payload, flag)
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(url, doc); err != nil {
+		return nil, fmt.Errorf("%s schema of flag %s: %w", payload, flag, err)
+	}
+	compiled, err := compiler.Compile(url)
+	if err != nil {
+		return nil, fmt.Errorf("%s schema of flag %s is invalid: %w", payload, flag, err)
+	}
+	return compiled, nil
+}
+
+// validatePayload checks the JSON document payload against schema.
+func validatePayload(schema *jsonschema.Schema, payload string) error {
+	doc, err := jsonschema.UnmarshalJSON(strings.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("not valid JSON: %w", err)
+	}
+	if err := schema.Validate(doc); err != nil {
+		return fmt.Errorf("%s", strings.ReplaceAll(strings.TrimSpace(err.Error()), "\n", "; "))
+	}
+	return nil
+}
+
+// checkSchemas returns a PARSE_ERROR detail and true when the treatment or config served for flag
+// does not satisfy the flag's schemas.
+func (p *SplitProvider) checkSchemas(flag string, result splitResult) (openfeature.ProviderResolutionDetail, bool) {
+	schema, ok := p.schemas[flag]
+	if !ok {
+		return openfeature.ProviderResolutionDetail{}, false
+	}
+	err := p.parsed.checkSchema(flag, schema, result.treatment, result.config)
+	if err == nil {
+		return openfeature.ProviderResolutionDetail{}, false
+	}
+	return openfeature.ProviderResolutionDetail{
+		ResolutionError: openfeature.NewParseErrorResolutionError(err.Error()),
+		Reason:          openfeature.ErrorReason,
+		Variant:         result.treatment,
+	}, true
+}
+
+func (s compiledSchema) check(treatment string, config *string) error {
+	if s.treatment != nil {
+		if err := validatePayload(s.treatment, treatment); err != nil {
+			return fmt.Errorf("treatment %q does not match its schema: %w", treatment, err)
+		}
+	}
+	if s.config != nil && config != nil {
+		if err := validatePayload(s.config, *config); err != nil {
+			return fmt.Errorf("config of treatment %q does not match its schema: %w", treatment, err)
+		}
+	}
+	return nil
+}
+
+// ValidateFlagSchemas checks every treatment and config of the flags configured with
+// WithFlagSchemas, as known to the Split manager, against their schemas, in flag name order. Like
+// ValidateFlags, it returns an error only when the definitions cannot be read; flags that are
+// missing or violate their schemas are reported in the FlagValidationReport. The contracts of the
+// results are of type OBJECT for flags with a treatment schema and STRING otherwise.
+func (p *SplitProvider) ValidateFlagSchemas() (FlagValidationReport, error) {
+	if p.currentManager() == nil {
+		return FlagValidationReport{}, ErrNoSplitManager
+	}
+	flags := make([]string, 0, len(p.schemas))
+	for flag := range p.schemas {
+		flags = append(flags, flag)
+	}
+	sort.Strings(flags)
+	report := FlagValidationReport{Results: make([]FlagValidationResult, 0, len(flags))}
+	for _, flag := range flags {
+		contract := FlagContract{Name: flag, Type: openfeature.String}
+		if p.schemas[flag].treatment != nil {
+			contract.Type = openfeature.Object
+		}
+		report.Results = append(report.Results, p.validateFlag(contract))
+	}
+	return report, nil
+}
+
+// schemaProblems lists the treatments and configs of definition that violate the flag's schemas.
+func (p *SplitProvider) schemaProblems(definition FlagDefinition) []string {
+	schema, ok := p.schemas[definition.Name]
+	if !ok {
+		return nil
+	}
+	var problems []string
+	for _, treatment := range definition.Treatments {
+		var config *string
+		if c, ok := definition.Config(treatment); ok {
+			config = &c
+		}
+		if err := schema.check(treatment, config); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	return problems
+}
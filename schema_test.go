@@ -0,0 +1,102 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+const bannerTreatmentSchema = `{
+	"type": "object",
+	"required": ["color"],
+	"properties": {"color": {"enum": ["blue", "green"]}}
+}`
+
+const bannerConfigSchema = `{
+	"type": "object",
+	"properties": {"size": {"type": "integer", "minimum": 1}}
+}`
+
+func schemaProvider(t *testing.T) *SplitProvider {
+	t.Helper()
+	provider, err := NewLocalhostProviderFromDefinitions([]LocalFlag{
+		{Name: "banner", Treatment: `{"color":"red"}`, Keys: []string{"red"}},
+		{Name: "banner", Treatment: `{"color":"blue"}`, Keys: []string{"tiny"}, Config: `{"size":0}`},
+		{Name: "banner", Treatment: `{"color":"green"}`, Config: `{"size":3}`},
+		{Name: "checkout", Treatment: "on", Config: `{"size":"large"}`},
+	}, quietSDK(), WithFlagSchemas(map[string]FlagSchema{
+		"banner":   {Treatment: bannerTreatmentSchema, Config: bannerConfigSchema},
+		"checkout": {Config: bannerConfigSchema},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(provider.Shutdown)
+	return provider
+}
+
+func TestWithFlagSchemas_Evaluation(t *testing.T) {
+	provider := schemaProvider(t)
+	ctx := context.Background()
+	evaluate := func(key string) openfeature.InterfaceResolutionDetail {
+		return provider.ObjectEvaluation(ctx, "banner", "default", openfeature.FlattenedContext{openfeature.TargetingKey: key})
+	}
+
+	if result := evaluate("user"); result.ResolutionError != (openfeature.ResolutionError{}) || result.Value.(map[string]any)["color"] != "green" {
+		t.Errorf("Expected the valid green banner, got %+v", result)
+	}
+	result := evaluate("red")
+	detail := result.ResolutionDetail()
+	if result.Value != "default" || detail.ErrorCode != openfeature.ParseErrorCode || !strings.Contains(detail.ErrorMessage, "/color") {
+		t.Errorf("Expected the default with a descriptive %s for red, got %+v", openfeature.ParseErrorCode, detail)
+	}
+	detail = evaluate("tiny").ResolutionDetail()
+	if detail.ErrorCode != openfeature.ParseErrorCode || !strings.Contains(detail.ErrorMessage, "config") || !strings.Contains(detail.ErrorMessage, "/size") {
+		t.Errorf("Expected the invalid config to be reported, got %+v", detail)
+	}
+
+	checkout := provider.BooleanEvaluation(ctx, "checkout", false, openfeature.FlattenedContext{openfeature.TargetingKey: "user"})
+	if checkout.Value != false || checkout.ResolutionDetail().ErrorCode != openfeature.ParseErrorCode {
+		t.Errorf("Expected config schemas to apply to any flag type, got %+v", checkout)
+	}
+}
+
+func TestValidateFlagSchemas(t *testing.T) {
+	provider := schemaProvider(t)
+
+	report, err := provider.ValidateFlagSchemas()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Results) != 2 || report.OK() {
+		t.Fatalf("Expected two failed results, got %+v", report)
+	}
+	banner, checkout := report.Results[0], report.Results[1]
+	if banner.Contract.Name != "banner" || banner.Contract.Type != openfeature.Object || len(banner.Problems) != 2 {
+		t.Errorf("Expected the red treatment and the tiny config to be reported, got %+v", banner)
+	}
+	if checkout.Contract.Type != openfeature.String || len(checkout.Problems) != 1 {
+		t.Errorf("Expected the checkout config to be reported, got %+v", checkout)
+	}
+
+	contracts, err := provider.ValidateFlags([]FlagContract{{Name: "checkout", Type: openfeature.Boolean}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contracts.OK() || !strings.Contains(contracts.Err().Error(), "config of treatment") {
+		t.Errorf("Expected ValidateFlags to report schema violations, got %v", contracts.Err())
+	}
+}
+
+func TestWithFlagSchemas_InvalidSchema(t *testing.T) {
+	for _, schema := range []FlagSchema{
+		{Treatment: `{"type":`},
+		{Config: `{"type": "whatever"}`},
+	} {
+		if _, err := newProviderOptions([]Option{WithFlagSchemas(map[string]FlagSchema{"banner": schema})}); err == nil {
+			t.Errorf("Expected an error for %+v", schema)
+		}
+	}
+}
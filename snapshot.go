@@ -0,0 +1,213 @@
+package split_openfeature_provider_go
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+const (
+	// StaleReason is the resolution reason of evaluations served from a snapshot while the Split SDK
+	// is not ready.
+	StaleReason openfeature.Reason = "STALE"
+	// Metadata keys set on evaluations served from a snapshot.
+	flagMetadataStaleKey      = "stale"
+	flagMetadataSnapshotAtKey = "snapshotSavedAt"
+	// staleReadyPollInterval is how often a provider serving a snapshot checks whether the Split SDK
+	// became ready.
+	staleReadyPollInterval = 250 * time.Millisecond
+)
+
+// snapshotFile is the on-disk format of a flag snapshot.
+type snapshotFile struct {
+	SavedAt time.Time      `json:"savedAt"`
+	Flags   []snapshotFlag `json:"flags"`
+}
+
+// snapshotFlag holds what a snapshot serves for a flag: its default treatment and that treatment's config.
+type snapshotFlag struct {
+	Name      string  `json:"name"`
+	Treatment string  `json:"treatment"`
+	Config    *string `json:"config,omitempty"`
+}
+
+// flagSnapshot is a loaded snapshot, ready to serve evaluations.
+type flagSnapshot struct {
+	savedAt time.Time
+	results map[string]splitResult
+}
+
+// snapshotter keeps a provider's snapshot file up to date and, when the provider starts from a
+// snapshot, switches it to live data once the Split SDK is ready.
+type snapshotter struct {
+	provider *SplitProvider
+	path     string
+	interval time.Duration
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// errSnapshotNotSupported is returned by the constructors that cannot save or serve snapshots.
+var errSnapshotNotSupported = errors.New("snapshots are not supported by NewProvider and NewRedisConsumerProvider")
+
+// WithSnapshot makes the provider save the flag definitions to path every interval while Split is
+// ready, so constructors that create the Split client can start from the last saved snapshot when
+// Split cannot be reached in time. NewProvider, which does not create the client, and
+// NewRedisConsumerProvider, whose definitions already persist in Redis, reject it. A provider
+// started from a snapshot serves each flag's default treatment (and its config) with reason STALE
+// and FlagMetadata["stale"] set, emits PROVIDER_STALE, and switches to live evaluations with a
+// PROVIDER_READY event once the SDK is ready. Flags missing from the snapshot, including those
+// whose default treatment is "control" as in localhost YAML files, resolve as if Split returned
+// "control". Snapshots do not include targeting rules and are meant as a degraded mode only.
+func WithSnapshot(path string, interval time.Duration) Option {
+	return func(o *providerOptions) {
+		o.snapshotPath = path
+		o.snapshotInterval = interval
+	}
+}
+
+// loadSnapshot reads the snapshot file at path.
+func loadSnapshot(path string) (*flagSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file snapshotFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	savedAt := file.SavedAt.UTC().Format(time.RFC3339)
+	snapshot := &flagSnapshot{savedAt: file.SavedAt, results: make(map[string]splitResult, len(file.Flags))}
+	for _, flag := range file.Flags {
+		meta := openfeature.FlagMetadata{flagMetadataStaleKey: true, flagMetadataSnapshotAtKey: savedAt}
+		if flag.Config != nil && *flag.Config != "" {
+			meta[flagMetadataConfigKey] = *flag.Config
+		}
+		snapshot.results[flag.Name] = splitResult{
+			treatment: flag.Treatment,
+			config:    flag.Config,
+			metadata:  meta,
+			stale:     true,
+		}
+	}
+	return snapshot, nil
+}
+
+// writeSnapshot saves the provider's current flag definitions to path, replacing it atomically.
+func (p *SplitProvider) writeSnapshot(path string) error {
+	definitions, err := p.Flags()
+	if err != nil {
+		return err
+	}
+	file := snapshotFile{SavedAt: time.Now().UTC(), Flags: make([]snapshotFlag, 0, len(definitions))}
+	for _, definition := range definitions {
+		// Localhost YAML and legacy definitions have no default treatment to serve.
+		if noTreatment(definition.DefaultTreatment) {
+			continue
+		}
+		flag := snapshotFlag{Name: definition.Name, Treatment: definition.DefaultTreatment}
+		if config, ok := definition.Config(definition.DefaultTreatment); ok {
+			flag.Config = &config
+		}
+		file.Flags = append(file.Flags, flag)
+	}
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// startSnapshots starts keeping the snapshot file up to date. When stale is not nil the provider
+// serves it until the Split SDK is ready.
+func (p *SplitProvider) startSnapshots(path string, interval time.Duration, stale *flagSnapshot) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s := &snapshotter{
+		provider: p,
+		path:     path,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	p.snapshots = s
+	if stale != nil {
+		p.stale.Store(stale)
+		p.emit(openfeature.ProviderStale, openfeature.ProviderEventDetails{
+			Message: "Split is not ready, serving the snapshot saved at " + stale.savedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	go s.run()
+}
+
+func (s *snapshotter) run() {
+	defer close(s.done)
+	if s.provider.stale.Load() != nil && !s.waitUntilReady() {
+		return
+	}
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		// Write errors are ignored: the previous snapshot stays in place and the next tick retries.
+		_ = s.provider.writeSnapshot(s.path)
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitUntilReady waits for the Split SDK to become ready and switches the provider to live data.
+// It returns false if the snapshotter was stopped first.
+func (s *snapshotter) waitUntilReady() bool {
+	ticker := time.NewTicker(staleReadyPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return false
+		case <-ticker.C:
+			if factory := s.provider.currentFactory(); factory != nil && factory.IsReady() {
+				s.provider.stale.Store(nil)
+				s.provider.emit(openfeature.ProviderReady, openfeature.ProviderEventDetails{
+					Message: "Split is ready, serving live data",
+				})
+				return true
+			}
+		}
+	}
+}
+
+// close stops the snapshotter and waits for it to exit.
+func (s *snapshotter) close() {
+	close(s.stop)
+	<-s.done
+}
+
+func validateSnapshotOptions(path string, interval time.Duration) error {
+	if path == "" {
+		return nil
+	}
+	if interval <= 0 {
+		return errors.New("snapshot interval must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/splitio/go-client/v6/splitio/conf"
+)
+
+func waitForSnapshot(t *testing.T, path string) snapshotFile {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if data, err := os.ReadFile(path); err == nil {
+			var file snapshotFile
+			if err := json.Unmarshal(data, &file); err != nil {
+				t.Fatal(err)
+			}
+			return file
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("Timed out waiting for the snapshot file")
+	return snapshotFile{}
+}
+
+// snapshotSplitFile is a localhost JSON file with flags that have default treatments and configs.
+const snapshotSplitFile = `{"ff": {"d": [
+  {"name": "checkout", "trafficTypeName": "user", "status": "ACTIVE", "killed": false,
+   "defaultTreatment": "v1", "changeNumber": 1, "algo": 2, "conditions": [],
+   "configurations": {"v1": "{\"color\":\"grey\"}"}},
+  {"name": "max_items", "trafficTypeName": "user", "status": "ACTIVE", "killed": false,
+   "defaultTreatment": "10", "changeNumber": 1, "algo": 2, "conditions": []}
+], "s": -1, "t": 1}}`
+
+func TestSnapshot_WritesFlagDefinitions(t *testing.T) {
+	dir := t.TempDir()
+	splitFile := filepath.Join(dir, "split.json")
+	if err := os.WriteFile(splitFile, []byte(snapshotSplitFile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "snapshot.json")
+	provider, err := NewLocalhostProvider(splitFile, quietSDK(), WithSnapshot(path, 20*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+
+	file := waitForSnapshot(t, path)
+	if file.SavedAt.IsZero() {
+		t.Error("Expected the snapshot time to be set")
+	}
+	flags := make(map[string]snapshotFlag)
+	for _, flag := range file.Flags {
+		flags[flag.Name] = flag
+	}
+	if checkout := flags["checkout"]; checkout.Treatment != "v1" || checkout.Config == nil || *checkout.Config != `{"color":"grey"}` {
+		t.Errorf("Expected checkout to default to v1 with its config, got %+v", checkout)
+	}
+	if flags["max_items"].Treatment != "10" {
+		t.Errorf("Expected max_items to default to 10, got %+v", flags["max_items"])
+	}
+}
+
+func TestSnapshot_ServesSnapshotUntilReady(t *testing.T) {
+	dir := t.TempDir()
+	snapshotPath := filepath.Join(dir, "snapshot.json")
+	config := `{"color":"grey"}`
+	data, err := json.Marshal(snapshotFile{
+		SavedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Flags:   []snapshotFlag{{Name: "checkout", Treatment: "v1", Config: &config}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(snapshotPath, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The split file does not exist, so the SDK cannot become ready.
+	cfg := conf.Default()
+	cfg.SplitFile = filepath.Join(dir, "missing.yaml")
+	provider, err := newProviderWithConfig(localhostAPIKey, cfg, []Option{
+		quietSDK(), WithReadyTimeout(time.Second), WithSnapshot(snapshotPath, time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+	ctx := context.Background()
+	flatCtx := openfeature.FlattenedContext{openfeature.TargetingKey: "key"}
+
+	if event := waitForEvent(t, provider.EventChannel()); event.EventType != openfeature.ProviderStale {
+		t.Errorf("Expected %s, got %s", openfeature.ProviderStale, event.EventType)
+	}
+	result := provider.StringEvaluation(ctx, "checkout", "default", flatCtx)
+	if result.Value != "v1" || result.Reason != StaleReason {
+		t.Errorf("Expected the snapshot treatment with reason STALE, got %+v", result)
+	}
+	if result.FlagMetadata[flagMetadataStaleKey] != true || result.FlagMetadata[flagMetadataConfigKey] != config {
+		t.Errorf("Expected stale metadata with the config, got %v", result.FlagMetadata)
+	}
+	if result.FlagMetadata[flagMetadataSnapshotAtKey] != "2026-01-02T03:04:05Z" {
+		t.Errorf("Expected the snapshot time in metadata, got %v", result.FlagMetadata)
+	}
+
+	live, err := NewLocalhostProviderFromDefinitions([]LocalFlag{{Name: "checkout", Treatment: "v2"}}, quietSDK())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Hand the live factory over to the provider, as if its own SDK had become ready.
+	live.mu.Lock()
+	factory := live.factory
+	live.factory = nil
+	live.mu.Unlock()
+	provider.swapFactory(factory).Destroy()
+	if event := waitForEvent(t, provider.EventChannel()); event.EventType != openfeature.ProviderReady {
+		t.Errorf("Expected %s, got %s", openfeature.ProviderReady, event.EventType)
+	}
+	result = provider.StringEvaluation(ctx, "checkout", "default", flatCtx)
+	if result.Value != "v2" || result.Reason != openfeature.TargetingMatchReason {
+		t.Errorf("Expected live evaluations once ready, got %+v", result)
+	}
+}
+
+func TestSnapshot_NoSnapshotFails(t *testing.T) {
+	cfg := conf.Default()
+	cfg.SplitFile = filepath.Join(t.TempDir(), "split.yaml")
+	_, err := newProviderWithConfig(localhostAPIKey, cfg, []Option{
+		quietSDK(), WithReadyTimeout(time.Second), WithSnapshot(filepath.Join(t.TempDir(), "missing.json"), time.Hour),
+	})
+	if err == nil {
+		t.Error("Expected an error when Split is not ready and there is no snapshot")
+	}
+}
+
+func TestSnapshot_InvalidOptions(t *testing.T) {
+	if _, err := newProviderOptions([]Option{WithSnapshot("snapshot.json", 0)}); err == nil {
+		t.Error("Expected an error for a zero snapshot interval")
+	}
+	if _, err := newProviderOptions([]Option{WithReadyTimeout(0)}); err == nil {
+		t.Error("Expected an error for a zero ready timeout")
+	}
+}
+
+func TestWithSnapshot_NotSupported(t *testing.T) {
+	option := WithSnapshot(filepath.Join(t.TempDir(), "snapshot.json"), time.Minute)
+	localhost := createProvider(t)
+	if _, err := NewProvider(localhost.currentClient(), option); !errors.Is(err, errSnapshotNotSupported) {
+		t.Errorf("Expected NewProvider to reject snapshots, got %v", err)
+	}
+	server := miniredis.RunT(t)
+	synchronize(t, server, "myapp")
+	if _, err := NewRedisConsumerProvider(context.Background(), "api-key", redisConfigFor(t, server, "myapp"), quietSDK(), option); !errors.Is(err, errSnapshotNotSupported) {
+		t.Errorf("Expected NewRedisConsumerProvider to reject snapshots, got %v", err)
+	}
+}
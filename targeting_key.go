@@ -0,0 +1,104 @@
+package split_openfeature_provider_go
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// TargetingKeyPolicy decides how the provider handles targeting keys that are not strings, which
+// reach it when the key is set as a "targetingKey" attribute or when the provider is called directly.
+// It applies to evaluations, Track and the context validation hook alike.
+type TargetingKeyPolicy int
+
+const (
+	// StrictTargetingKeys rejects non-string targeting keys with INVALID_CONTEXT. It is the default.
+	StrictTargetingKeys TargetingKeyPolicy = iota
+	// LenientTargetingKeys converts integers, floats holding integers (e.g. numbers decoded from
+	// JSON) and fmt.Stringer values such as UUIDs to their decimal or String() form. Other types are
+	// still rejected with INVALID_CONTEXT.
+	LenientTargetingKeys
+)
+
+// WithTargetingKeyPolicy sets how non-string targeting keys are handled (StrictTargetingKeys by default).
+func WithTargetingKeyPolicy(policy TargetingKeyPolicy) Option {
+	return func(o *providerOptions) {
+		o.keyPolicy = policy
+	}
+}
+
+func (policy TargetingKeyPolicy) validate() error {
+	if policy != StrictTargetingKeys && policy != LenientTargetingKeys {
+		return fmt.Errorf("unknown targeting key policy %d", policy)
+	}
+	return nil
+}
+
+// normalize returns flatCtx with its targeting key as a string, or an INVALID_CONTEXT resolution error
+// if the policy rejects it. Contexts whose key is absent or already a string are returned as is;
+// converted keys are set on a copy so the caller's context is never modified.
+func (policy TargetingKeyPolicy) normalize(flatCtx openfeature.FlattenedContext) (openfeature.FlattenedContext, error) {
+	value, ok := flatCtx[openfeature.TargetingKey]
+	if !ok || value == nil {
+		return flatCtx, nil
+	}
+	if _, isString := value.(string); isString {
+		return flatCtx, nil
+	}
+	key, converted := "", false
+	if policy == LenientTargetingKeys {
+		key, converted = stringifyTargetingKey(value)
+	}
+	if !converted {
+		return nil, openfeature.NewInvalidContextResolutionError(fmt.Sprintf("targeting key must be a string, got %T", value))
+	}
+	normalized := make(openfeature.FlattenedContext, len(flatCtx))
+	for k, v := range flatCtx {
+		normalized[k] = v
+	}
+	normalized[openfeature.TargetingKey] = key
+	return normalized, nil
+}
+
+// stringifyTargetingKey converts the key types accepted by LenientTargetingKeys.
+func stringifyTargetingKey(value any) (string, bool) {
+	switch v := value.(type) {
+	case int:
+		return strconv.FormatInt(int64(v), 10), true
+	case int8:
+		return strconv.FormatInt(int64(v), 10), true
+	case int16:
+		return strconv.FormatInt(int64(v), 10), true
+	case int32:
+		return strconv.FormatInt(int64(v), 10), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case uint:
+		return strconv.FormatUint(uint64(v), 10), true
+	case uint8:
+		return strconv.FormatUint(uint64(v), 10), true
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10), true
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10), true
+	case uint64:
+		return strconv.FormatUint(v, 10), true
+	case float32:
+		return integralFloatKey(float64(v))
+	case float64:
+		return integralFloatKey(v)
+	case fmt.Stringer:
+		return v.String(), true
+	}
+	return "", false
+}
+
+// integralFloatKey formats f without a fractional part or exponent, as long as it holds an integer.
+func integralFloatKey(f float64) (string, bool) {
+	if math.IsInf(f, 0) || math.IsNaN(f) || f != math.Trunc(f) {
+		return "", false
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64), true
+}
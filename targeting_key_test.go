@@ -0,0 +1,114 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+type accountID [4]byte
+
+func (a accountID) String() string { return "acct-0102" }
+
+func TestTargetingKeyPolicy_Normalize(t *testing.T) {
+	tests := []struct {
+		value    any
+		lenient  string
+		accepted bool
+	}{
+		{value: 42, lenient: "42", accepted: true},
+		{value: int64(-7), lenient: "-7", accepted: true},
+		{value: uint32(9), lenient: "9", accepted: true},
+		{value: float64(1234567890), lenient: "1234567890", accepted: true},
+		{value: 1.5},
+		{value: accountID{1, 2}, lenient: "acct-0102", accepted: true},
+		{value: true},
+		{value: []string{"a"}},
+	}
+	for _, test := range tests {
+		flatCtx := openfeature.FlattenedContext{openfeature.TargetingKey: test.value, "plan": "pro"}
+
+		if _, err := StrictTargetingKeys.normalize(flatCtx); err == nil {
+			t.Errorf("Expected the strict policy to reject %T", test.value)
+		} else {
+			requireResolutionCode(t, err, openfeature.InvalidContextCode)
+		}
+
+		normalized, err := LenientTargetingKeys.normalize(flatCtx)
+		if !test.accepted {
+			if err == nil {
+				t.Errorf("Expected the lenient policy to reject %#v", test.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for %#v: %v", test.value, err)
+			continue
+		}
+		if normalized[openfeature.TargetingKey] != test.lenient || normalized["plan"] != "pro" {
+			t.Errorf("Expected key %q, got %v", test.lenient, normalized)
+		}
+		if flatCtx[openfeature.TargetingKey] != test.value {
+			t.Error("Expected the original context to be left untouched")
+		}
+	}
+}
+
+func TestTargetingKeyPolicy_StringKeysUnchanged(t *testing.T) {
+	for _, flatCtx := range []openfeature.FlattenedContext{
+		{openfeature.TargetingKey: "key"},
+		{"plan": "pro"},
+	} {
+		for _, policy := range []TargetingKeyPolicy{StrictTargetingKeys, LenientTargetingKeys} {
+			if _, err := policy.normalize(flatCtx); err != nil {
+				t.Errorf("Unexpected error for %v: %v", flatCtx, err)
+			}
+		}
+	}
+}
+
+func TestTargetingKeyPolicy_Evaluation(t *testing.T) {
+	flags := []LocalFlag{{Name: "checkout", Treatment: "on", Keys: []string{"42"}}, {Name: "checkout", Treatment: "off"}}
+	flatCtx := openfeature.FlattenedContext{openfeature.TargetingKey: 42}
+	ctx := context.Background()
+
+	strict, err := NewLocalhostProviderFromDefinitions(flags, quietSDK())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer strict.Shutdown()
+	result := strict.BooleanEvaluation(ctx, "checkout", false, flatCtx)
+	if result.ResolutionDetail().ErrorCode != openfeature.InvalidContextCode || result.Reason != openfeature.ErrorReason {
+		t.Errorf("Expected %s for a numeric key by default, got %+v", openfeature.InvalidContextCode, result)
+	}
+
+	lenient, err := NewLocalhostProviderFromDefinitions(flags, quietSDK(), WithTargetingKeyPolicy(LenientTargetingKeys))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lenient.Shutdown()
+	if result := lenient.BooleanEvaluation(ctx, "checkout", false, flatCtx); result.Value != true {
+		t.Errorf("Expected the numeric key to be evaluated as \"42\", got %+v", result)
+	}
+}
+
+func TestTargetingKeyPolicy_ValidationHook(t *testing.T) {
+	evalCtx := openfeature.NewTargetlessEvaluationContext(map[string]any{openfeature.TargetingKey: 42})
+	hints := openfeature.NewHookHints(nil)
+
+	strict := &contextValidationHook{limits: DefaultContextLimits()}
+	_, err := strict.Before(context.Background(), hookContextFor(evalCtx), hints)
+	requireResolutionCode(t, err, openfeature.InvalidContextCode)
+
+	lenient := &contextValidationHook{limits: DefaultContextLimits(), keyPolicy: LenientTargetingKeys}
+	if _, err := lenient.Before(context.Background(), hookContextFor(evalCtx), hints); err != nil {
+		t.Errorf("Expected the lenient hook to accept a numeric key, got %v", err)
+	}
+}
+
+func TestTargetingKeyPolicy_Invalid(t *testing.T) {
+	if _, err := newProviderOptions([]Option{WithTargetingKeyPolicy(TargetingKeyPolicy(7))}); err == nil {
+		t.Error("Expected an error for an unknown policy")
+	}
+}
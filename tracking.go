@@ -0,0 +1,166 @@
+package split_openfeature_provider_go
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/splitio/go-client/v6/splitio/client"
+)
+
+const (
+	// maxTrackProperties is the number of properties Split keeps per event.
+	maxTrackProperties = 300
+	// maxPropertyDepth bounds how deep nested property maps are flattened.
+	maxPropertyDepth = 8
+	// baseTrackEventSize is the size the Split SDK assumes for an event before adding its properties.
+	baseTrackEventSize = 1024
+)
+
+var eventNamePattern = regexp.MustCompile(client.RegExpEventType)
+
+// ErrTrackingEventRejected is wrapped by the errors reported for tracking events that are not sent.
+var ErrTrackingEventRejected = errors.New("tracking event rejected")
+
+// DroppedProperty is a tracking event property removed before the event was sent to Split.
+type DroppedProperty struct {
+	// Name is the property name, dotted for properties of nested maps.
+	Name   string
+	Reason string
+}
+
+// TrackReport describes a Track call that did not send the event as given: Dropped lists the
+// properties that were removed and Err, when not nil, why the event was not sent at all.
+type TrackReport struct {
+	EventName string
+	Dropped   []DroppedProperty
+	Err       error
+}
+
+// TrackReporter receives a TrackReport for every Track call that dropped properties or did not send
+// the event. It is called synchronously from Track and must be safe for concurrent use.
+type TrackReporter func(TrackReport)
+
+// WithTrackReporter registers reporter to find out about tracking events that were changed or
+// rejected; without it they are dropped silently, as Split itself does.
+func WithTrackReporter(reporter TrackReporter) Option {
+	return func(o *providerOptions) {
+		o.trackReporter = reporter
+	}
+}
+
+// ValidateTrackingEventName checks name against the event name format Split accepts.
+func ValidateTrackingEventName(name string) error {
+	if !eventNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: event name %q must match %s", ErrTrackingEventRejected, name, client.RegExpEventType)
+	}
+	return nil
+}
+
+// NormalizeTrackingProperties converts tracking event attributes into the flat properties Split
+// accepts. Nested maps are flattened with dotted names ("address.city"), time.Time values become
+// RFC 3339 strings and integers of any size become int64 or uint64. Other types, empty and
+// duplicate names and properties beyond Split's 300 property limit are dropped and returned, in name
+// order. The result is nil when no property is left.
+func NormalizeTrackingProperties(attributes map[string]any) (map[string]interface{}, []DroppedProperty) {
+	if len(attributes) == 0 {
+		return nil, nil
+	}
+	n := &propertyNormalizer{properties: make(map[string]interface{}, len(attributes))}
+	n.add("", attributes, 0)
+	sort.Slice(n.dropped, func(i, j int) bool { return n.dropped[i].Name < n.dropped[j].Name })
+	if len(n.properties) == 0 {
+		return nil, n.dropped
+	}
+	return n.properties, n.dropped
+}
+
+type propertyNormalizer struct {
+	properties map[string]interface{}
+	dropped    []DroppedProperty
+}
+
+func (n *propertyNormalizer) drop(name, reason string) {
+	n.dropped = append(n.dropped, DroppedProperty{Name: name, Reason: reason})
+}
+
+// add flattens attrs into the properties under prefix. Names are visited in order so duplicates and
+// the property limit resolve deterministically.
+func (n *propertyNormalizer) add(prefix string, attrs map[string]any, depth int) {
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		full := prefix + name
+		if name == "" {
+			n.drop(full, "empty name")
+			continue
+		}
+		switch v := attrs[name].(type) {
+		case map[string]any:
+			if depth >= maxPropertyDepth {
+				n.drop(full, fmt.Sprintf("nested deeper than %d levels", maxPropertyDepth))
+				continue
+			}
+			n.add(full+".", v, depth+1)
+		case map[string]string:
+			nested := make(map[string]any, len(v))
+			for k, s := range v {
+				nested[k] = s
+			}
+			n.add(full+".", nested, depth+1)
+		default:
+			value, ok := propertyValue(v)
+			switch {
+			case !ok:
+				n.drop(full, fmt.Sprintf("unsupported type %T", v))
+			case n.has(full):
+				n.drop(full, "duplicate name")
+			case len(n.properties) >= maxTrackProperties:
+				n.drop(full, fmt.Sprintf("more than %d properties", maxTrackProperties))
+			default:
+				n.properties[full] = value
+			}
+		}
+	}
+}
+
+func (n *propertyNormalizer) has(name string) bool {
+	_, ok := n.properties[name]
+	return ok
+}
+
+// propertyValue converts value to a type the Split SDK sends as a property.
+func propertyValue(value any) (interface{}, bool) {
+	switch v := value.(type) {
+	case nil, string, bool, int, int32, int64, uint, uint32, uint64, float32, float64:
+		return v, true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case uint8:
+		return uint64(v), true
+	case uint16:
+		return uint64(v), true
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano), true
+	}
+	return nil, false
+}
+
+// trackPropertiesSize estimates the event size the way the Split SDK does before queueing it.
+func trackPropertiesSize(properties map[string]interface{}) int {
+	size := baseTrackEventSize
+	for name, value := range properties {
+		size += len(name)
+		if s, ok := value.(string); ok {
+			size += len(s)
+		}
+	}
+	return size
+}
@@ -0,0 +1,91 @@
+package split_openfeature_provider_go
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// trafficTypeAttribute is the evaluation context attribute Track reads the Split traffic type from.
+const trafficTypeAttribute = "trafficType"
+
+// PropertyPrecedence decides which value Track sends when an event attribute and a context
+// attribute merged by WithContextTrackingProperties have the same name.
+type PropertyPrecedence int
+
+const (
+	// EventPropertiesFirst keeps the value given in the TrackingEventDetails. It is the default.
+	EventPropertiesFirst PropertyPrecedence = iota
+	// ContextAttributesFirst keeps the value from the evaluation context.
+	ContextAttributesFirst
+)
+
+// contextTracking holds the settings given with WithContextTrackingProperties.
+type contextTracking struct {
+	enabled    bool
+	precedence PropertyPrecedence
+	// names lists the context attributes to merge; all of them when empty.
+	names []string
+}
+
+// WithContextTrackingProperties makes Track add evaluation context attributes to the event
+// properties, so events can be sliced by the same attributes flags are targeted on. Only the named
+// attributes are added, or all of them when no name is given. Attributes are taken after the
+// ContextMapper is applied; the targeting key and the trafficType attribute are never added.
+// precedence decides which value wins when the event details carry a property of the same name.
+// Merged attributes are normalized like any other property (see NormalizeTrackingProperties).
+func WithContextTrackingProperties(precedence PropertyPrecedence, names ...string) Option {
+	return func(o *providerOptions) {
+		o.contextTracking = contextTracking{
+			enabled:    true,
+			precedence: precedence,
+			names:      append([]string(nil), names...),
+		}
+	}
+}
+
+func (c contextTracking) validate() error {
+	if c.precedence != EventPropertiesFirst && c.precedence != ContextAttributesFirst {
+		return fmt.Errorf("unknown property precedence %d", c.precedence)
+	}
+	for _, name := range c.names {
+		if name == "" {
+			return errors.New("context tracking property names cannot be empty")
+		}
+	}
+	return nil
+}
+
+// merge returns the event attributes with the selected context attributes added. Neither map is
+// modified.
+func (c contextTracking) merge(event map[string]any, context map[string]interface{}) map[string]any {
+	if !c.enabled || len(context) == 0 {
+		return event
+	}
+	merged := make(map[string]any, len(event)+len(context))
+	add := func(name string, value interface{}) {
+		if name == trafficTypeAttribute || name == openfeature.TargetingKey {
+			return
+		}
+		if _, exists := event[name]; exists && c.precedence == EventPropertiesFirst {
+			return
+		}
+		merged[name] = value
+	}
+	for name, value := range event {
+		merged[name] = value
+	}
+	if len(c.names) == 0 {
+		for name, value := range context {
+			add(name, value)
+		}
+		return merged
+	}
+	for _, name := range c.names {
+		if value, ok := context[name]; ok {
+			add(name, value)
+		}
+	}
+	return merged
+}
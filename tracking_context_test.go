@@ -0,0 +1,52 @@
+package split_openfeature_provider_go
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestContextTracking_Merge(t *testing.T) {
+	event := map[string]any{"plan": "event", "value": 1}
+	context := map[string]interface{}{"plan": "pro", "region": "eu", "trafficType": "user", "age": 30}
+
+	tests := []struct {
+		tracking contextTracking
+		expected map[string]any
+	}{
+		{
+			tracking: contextTracking{},
+			expected: event,
+		},
+		{
+			tracking: contextTracking{enabled: true},
+			expected: map[string]any{"plan": "event", "value": 1, "region": "eu", "age": 30},
+		},
+		{
+			tracking: contextTracking{enabled: true, precedence: ContextAttributesFirst},
+			expected: map[string]any{"plan": "pro", "value": 1, "region": "eu", "age": 30},
+		},
+		{
+			tracking: contextTracking{enabled: true, names: []string{"region", "missing"}},
+			expected: map[string]any{"plan": "event", "value": 1, "region": "eu"},
+		},
+	}
+	for _, test := range tests {
+		if merged := test.tracking.merge(event, context); !reflect.DeepEqual(merged, test.expected) {
+			t.Errorf("Expected %v for %+v, got %v", test.expected, test.tracking, merged)
+		}
+	}
+	if event["plan"] != "event" || len(event) != 2 {
+		t.Errorf("Expected the event attributes to be left untouched, got %v", event)
+	}
+}
+
+func TestWithContextTrackingProperties_Invalid(t *testing.T) {
+	for _, opt := range []Option{
+		WithContextTrackingProperties(PropertyPrecedence(5)),
+		WithContextTrackingProperties(EventPropertiesFirst, "plan", ""),
+	} {
+		if _, err := newProviderOptions([]Option{opt}); err == nil {
+			t.Error("Expected an error")
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+func TestNormalizeTrackingProperties(t *testing.T) {
+	at := time.Date(2026, 3, 20, 10, 30, 0, 0, time.FixedZone("CET", 3600))
+	properties, dropped := NormalizeTrackingProperties(map[string]any{
+		"plan":    "pro",
+		"seats":   int8(5),
+		"trial":   false,
+		"coupon":  nil,
+		"at":      at,
+		"address": map[string]any{"city": "Lisbon", "geo": map[string]any{"lat": 38.7}},
+		"labels":  map[string]string{"team": "growth"},
+		"items":   []string{"a", "b"},
+		"":        "empty",
+	})
+	expected := map[string]interface{}{
+		"plan":            "pro",
+		"seats":           int64(5),
+		"trial":           false,
+		"coupon":          nil,
+		"at":              "2026-03-20T09:30:00Z",
+		"address.city":    "Lisbon",
+		"address.geo.lat": 38.7,
+		"labels.team":     "growth",
+	}
+	if !reflect.DeepEqual(properties, expected) {
+		t.Errorf("Expected %v, got %v", expected, properties)
+	}
+	if len(dropped) != 2 || dropped[0].Name != "" || dropped[1].Name != "items" {
+		t.Errorf("Expected the empty name and the slice to be dropped, got %+v", dropped)
+	}
+}
+
+func TestNormalizeTrackingProperties_Duplicates(t *testing.T) {
+	properties, dropped := NormalizeTrackingProperties(map[string]any{
+		"address":      map[string]any{"city": "Lisbon"},
+		"address.city": "Porto",
+	})
+	if len(properties) != 1 || len(dropped) != 1 || dropped[0].Reason != "duplicate name" {
+		t.Errorf("Expected one property and one duplicate, got %v %+v", properties, dropped)
+	}
+}
+
+func TestNormalizeTrackingProperties_Limits(t *testing.T) {
+	attrs := make(map[string]any, maxTrackProperties+5)
+	for i := 0; i < maxTrackProperties+5; i++ {
+		attrs[strings.Repeat("p", i+1)] = i
+	}
+	properties, dropped := NormalizeTrackingProperties(attrs)
+	if len(properties) != maxTrackProperties || len(dropped) != 5 {
+		t.Errorf("Expected %d properties and 5 dropped, got %d and %d", maxTrackProperties, len(properties), len(dropped))
+	}
+
+	nested := map[string]any{"leaf": 1}
+	for i := 0; i <= maxPropertyDepth; i++ {
+		nested = map[string]any{"n": nested}
+	}
+	properties, dropped = NormalizeTrackingProperties(nested)
+	if properties != nil || len(dropped) != 1 {
+		t.Errorf("Expected the deep map to be dropped, got %v %+v", properties, dropped)
+	}
+}
+
+func TestValidateTrackingEventName(t *testing.T) {
+	for _, name := range []string{"checkout", "checkout.completed", "page:view", "a-b_c"} {
+		if err := ValidateTrackingEventName(name); err != nil {
+			t.Errorf("Unexpected error for %q: %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "-checkout", "check out", strings.Repeat("e", 81)} {
+		if err := ValidateTrackingEventName(name); !errors.Is(err, ErrTrackingEventRejected) {
+			t.Errorf("Expected %q to be rejected, got %v", name, err)
+		}
+	}
+}
+
+func TestTrack_Reporter(t *testing.T) {
+	var mu sync.Mutex
+	var reports []TrackReport
+	provider, err := NewLocalhostProviderFromDefinitions([]LocalFlag{{Name: "checkout", Treatment: "on"}}, quietSDK(),
+		WithTrackReporter(func(report TrackReport) {
+			mu.Lock()
+			defer mu.Unlock()
+			reports = append(reports, report)
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+	ctx := context.Background()
+	evalCtx := openfeature.NewEvaluationContext("user", map[string]any{"trafficType": "user"})
+
+	provider.Track(ctx, "checkout", evalCtx, openfeature.NewTrackingEventDetails(1).Add("plan", "pro"))
+	provider.Track(ctx, "checkout", evalCtx, openfeature.NewTrackingEventDetails(1).Add("items", []int{1}))
+	provider.Track(ctx, "check out", evalCtx, openfeature.NewTrackingEventDetails(1))
+	provider.Track(ctx, "checkout", openfeature.NewEvaluationContext("user", nil), openfeature.NewTrackingEventDetails(1))
+	provider.Track(ctx, "checkout", evalCtx,
+		openfeature.NewTrackingEventDetails(1).Add("payload", strings.Repeat("x", 32*1024)))
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(reports) != 4 {
+		t.Fatalf("Expected 4 reports, got %+v", reports)
+	}
+	if reports[0].Err != nil || len(reports[0].Dropped) != 1 || reports[0].Dropped[0].Name != "items" {
+		t.Errorf("Expected the sent event to report the dropped property, got %+v", reports[0])
+	}
+	for _, report := range reports[1:] {
+		if !errors.Is(report.Err, ErrTrackingEventRejected) {
+			t.Errorf("Expected the event to be rejected, got %+v", report)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/splitio/go-client/v6/splitio/client"
+)
+
+// ContextLimits bounds the evaluation contexts accepted by the provider's validation hook.
+// A zero MaxAttributes or MaxAttributeSize disables that particular check.
+type ContextLimits struct {
+	// MaxKeyLength is the maximum targeting key length. Split rejects keys longer than 250 characters.
+	MaxKeyLength int
+	// MaxAttributes is the maximum number of attributes, not counting the targeting key.
+	MaxAttributes int
+	// MaxAttributeSize is the maximum length of a string attribute or number of elements in a set attribute.
+	MaxAttributeSize int
+}
+
+// DefaultContextLimits returns the limits used when no WithContextLimits option is given:
+// Split's targeting key length limit and no attribute limits.
+func DefaultContextLimits() ContextLimits {
+	return ContextLimits{
+		MaxKeyLength: client.MaxLength,
+	}
+}
+
+func (l ContextLimits) validate() error {
+	if l.MaxKeyLength <= 0 || l.MaxKeyLength > client.MaxLength {
+		return fmt.Errorf("MaxKeyLength must be between 1 and %d, got %d", client.MaxLength, l.MaxKeyLength)
+	}
+	if l.MaxAttributes < 0 {
+		return errors.New("MaxAttributes cannot be negative")
+	}
+	if l.MaxAttributeSize < 0 {
+		return errors.New("MaxAttributeSize cannot be negative")
+	}
+	return nil
+}
+
+// contextValidationHook is the provider hook that rejects malformed evaluation contexts
+// before they reach any of the *Evaluation methods.
+type contextValidationHook struct {
+	openfeature.UnimplementedHook
+	limits ContextLimits
+	// mapper is the custom ContextMapper of the provider, nil for the default mapping.
+	mapper    ContextMapper
+	keyPolicy TargetingKeyPolicy
+	deriver   KeyDeriver
+}
+
+// Before validates the merged evaluation context of the invocation, or the key and attributes the
+// custom ContextMapper derives from it. Contexts without a string targeting key go through the
+// TargetingKeyPolicy and mapping as in the *Evaluation methods, so a "targetingKey" attribute is
+// judged the same way there and here. The EvaluationPropertiesAttribute attribute is not validated.
+// The returned error is a openfeature.ResolutionError with code TARGETING_KEY_MISSING or
+// INVALID_CONTEXT.
+func (h *contextValidationHook) Before(ctx context.Context, hookContext openfeature.HookContext, hookHints openfeature.HookHints) (*openfeature.EvaluationContext, error) {
+	evalCtx := hookContext.EvaluationContext()
+	if evalCtx.Attribute(EvaluationPropertiesAttribute) != nil {
+		attrs := evalCtx.Attributes()
+		delete(attrs, EvaluationPropertiesAttribute)
+		evalCtx = openfeature.NewEvaluationContext(evalCtx.TargetingKey(), attrs)
+	}
+	if h.mapper != nil || evalCtx.TargetingKey() == "" {
+		flatCtx, err := h.keyPolicy.normalize(flattenEvaluationContext(evalCtx))
+		if err != nil {
+			return nil, err
+		}
+		mapper := h.mapper
+		if mapper == nil {
+			mapper = DefaultContextMapper()
+		}
+		key, attrs := mapper.Map(flatCtx)
+		if key == "" {
+			key, _ = h.deriver.derive(flatCtx)
+		}
+		evalCtx = openfeature.NewEvaluationContext(key, attrs)
+	}
+	if err := h.limits.check(evalCtx); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// check returns a resolution error describing the first problem found in evalCtx, or nil.
+// Attributes are inspected in name order so the reported problem is deterministic.
+func (l ContextLimits) check(evalCtx openfeature.EvaluationContext) error {
+	key := evalCtx.TargetingKey()
+	if key == "" {
+		return openfeature.NewTargetingKeyMissingResolutionError("targeting key is required and missing")
+	}
+	if len(key) > l.MaxKeyLength {
+		return openfeature.NewInvalidContextResolutionError(
+			fmt.Sprintf("targeting key is %d characters long, maximum is %d", len(key), l.MaxKeyLength))
+	}
+	attrs := evalCtx.Attributes()
+	if l.MaxAttributes > 0 && len(attrs) > l.MaxAttributes {
+		return openfeature.NewInvalidContextResolutionError(
+			fmt.Sprintf("evaluation context has %d attributes, maximum is %d", len(attrs), l.MaxAttributes))
+	}
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if msg := l.checkAttribute(attrs[name]); msg != "" {
+			return openfeature.NewInvalidContextResolutionError(fmt.Sprintf("attribute %q %s", name, msg))
+		}
+	}
+	return nil
+}
+
+// checkAttribute returns why value cannot be sent to Split as an attribute, or "" if it can.
+func (l ContextLimits) checkAttribute(value any) string {
+	switch v := value.(type) {
+	case nil, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return ""
+	case string:
+		if l.MaxAttributeSize > 0 && len(v) > l.MaxAttributeSize {
+			return fmt.Sprintf("is %d characters long, maximum is %d", len(v), l.MaxAttributeSize)
+		}
+		return ""
+	case []string:
+		return l.checkSetSize(len(v))
+	case []any:
+		for i, elem := range v {
+			if !isScalarAttribute(elem) {
+				return fmt.Sprintf("has unsupported element type %T at index %d", elem, i)
+			}
+		}
+		return l.checkSetSize(len(v))
+	default:
+		return fmt.Sprintf("has unsupported type %T", value)
+	}
+}
+
+func (l ContextLimits) checkSetSize(size int) string {
+	if l.MaxAttributeSize > 0 && size > l.MaxAttributeSize {
+		return fmt.Sprintf("has %d elements, maximum is %d", size, l.MaxAttributeSize)
+	}
+	return ""
+}
+
+func isScalarAttribute(value any) bool {
+	switch value.(type) {
+	case string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+func hookContextFor(evalCtx openfeature.EvaluationContext) openfeature.HookContext {
+	return openfeature.NewHookContext("my_feature", openfeature.Boolean, false, openfeature.ClientMetadata{}, openfeature.Metadata{Name: "Split"}, evalCtx)
+}
+
+func runValidationHook(t *testing.T, limits ContextLimits, evalCtx openfeature.EvaluationContext) error {
+	t.Helper()
+	hook := &contextValidationHook{limits: limits}
+	_, err := hook.Before(context.Background(), hookContextFor(evalCtx), openfeature.NewHookHints(nil))
+	return err
+}
+
+func requireResolutionCode(t *testing.T, err error, code openfeature.ErrorCode) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("Expected %s error, got nil", code)
+	}
+	var resErr openfeature.ResolutionError
+	if !errors.As(err, &resErr) {
+		t.Fatalf("Expected a ResolutionError, got %T: %v", err, err)
+	}
+	if !strings.HasPrefix(resErr.Error(), string(code)) {
+		t.Errorf("Expected %s error, got %s", code, resErr.Error())
+	}
+}
+
+func TestValidationHook_ValidContext(t *testing.T) {
+	evalCtx := openfeature.NewEvaluationContext("key", map[string]any{
+		"plan":    "pro",
+		"age":     42,
+		"score":   1.5,
+		"beta":    true,
+		"groups":  []string{"a", "b"},
+		"regions": []any{"eu", "us"},
+		"missing": nil,
+	})
+	if err := runValidationHook(t, DefaultContextLimits(), evalCtx); err != nil {
+		t.Errorf("Unexpected error for valid context: %v", err)
+	}
+}
+
+func TestValidationHook_MissingTargetingKey(t *testing.T) {
+	err := runValidationHook(t, DefaultContextLimits(), openfeature.NewEvaluationContext("", map[string]any{"plan": "pro"}))
+	requireResolutionCode(t, err, openfeature.TargetingKeyMissingCode)
+}
+
+func TestValidationHook_KeyTooLong(t *testing.T) {
+	err := runValidationHook(t, DefaultContextLimits(), openfeature.NewEvaluationContext(strings.Repeat("k", 251), nil))
+	requireResolutionCode(t, err, openfeature.InvalidContextCode)
+	if !strings.Contains(err.Error(), "251") {
+		t.Errorf("Expected message to mention the key length, got %s", err.Error())
+	}
+}
+
+func TestValidationHook_UnsupportedAttributeType(t *testing.T) {
+	evalCtx := openfeature.NewEvaluationContext("key", map[string]any{"nested": map[string]any{"a": 1}})
+	err := runValidationHook(t, DefaultContextLimits(), evalCtx)
+	requireResolutionCode(t, err, openfeature.InvalidContextCode)
+	if !strings.Contains(err.Error(), `"nested"`) {
+		t.Errorf("Expected message to name the attribute, got %s", err.Error())
+	}
+
+	evalCtx = openfeature.NewEvaluationContext("key", map[string]any{"set": []any{"a", []int{1}}})
+	err = runValidationHook(t, DefaultContextLimits(), evalCtx)
+	requireResolutionCode(t, err, openfeature.InvalidContextCode)
+}
+
+func TestValidationHook_AttributeLimits(t *testing.T) {
+	limits := DefaultContextLimits()
+	limits.MaxAttributes = 2
+	limits.MaxAttributeSize = 3
+
+	err := runValidationHook(t, limits, openfeature.NewEvaluationContext("key", map[string]any{"a": 1, "b": 2, "c": 3}))
+	requireResolutionCode(t, err, openfeature.InvalidContextCode)
+
+	err = runValidationHook(t, limits, openfeature.NewEvaluationContext("key", map[string]any{"a": "abcd"}))
+	requireResolutionCode(t, err, openfeature.InvalidContextCode)
+
+	err = runValidationHook(t, limits, openfeature.NewEvaluationContext("key", map[string]any{"a": []string{"1", "2", "3", "4"}}))
+	requireResolutionCode(t, err, openfeature.InvalidContextCode)
+
+	if err := runValidationHook(t, limits, openfeature.NewEvaluationContext("key", map[string]any{"a": "abc", "b": []string{"1"}})); err != nil {
+		t.Errorf("Unexpected error within limits: %v", err)
+	}
+}
+
+func TestValidationHook_InvalidLimits(t *testing.T) {
+	provider := createProvider(t)
+	if _, err := NewProvider(provider.currentClient(), WithContextLimits(ContextLimits{MaxKeyLength: 300})); err == nil {
+		t.Error("Expected error for MaxKeyLength above Split's limit")
+	}
+	if _, err := NewProvider(provider.currentClient(), WithContextLimits(ContextLimits{MaxKeyLength: 10, MaxAttributes: -1})); err == nil {
+		t.Error("Expected error for negative MaxAttributes")
+	}
+}
+
+func TestValidationHook_ThroughClient(t *testing.T) {
+	ofClient := create(t, WithContextValidation())
+	evalCtx := openfeature.NewEvaluationContext("key", map[string]any{"nested": map[string]any{"a": 1}})
+
+	result, err := ofClient.BooleanValue(context.Background(), "my_feature", false, evalCtx)
+	if err == nil {
+		t.Fatal("Expected invalid context error")
+	}
+	if !strings.Contains(err.Error(), string(openfeature.InvalidContextCode)) {
+		t.Errorf("Expected INVALID_CONTEXT error, got %s", err.Error())
+	}
+	if result != false {
+		t.Error("Result should have been the default value")
+	}
+}
+
+func TestValidationHook_OptIn(t *testing.T) {
+	if hooks := createProvider(t).Hooks(); len(hooks) != 0 {
+		t.Fatalf("Expected no validation hook by default, got %d hooks", len(hooks))
+	}
+	ofClient := create(t)
+	ctx := context.Background()
+
+	details, err := ofClient.BooleanValueDetails(ctx, "my_feature", false, openfeature.NewTargetlessEvaluationContext(nil))
+	if err == nil || details.ErrorCode != openfeature.TargetingKeyMissingCode || details.Reason != openfeature.ErrorReason {
+		t.Errorf("Expected %s with reason %s, got %+v", openfeature.TargetingKeyMissingCode, openfeature.ErrorReason, details)
+	}
+	evalCtx := openfeature.NewEvaluationContext("key", map[string]any{"since": time.Now(), "nested": map[string]any{"a": 1}})
+	if details, err := ofClient.BooleanValueDetails(ctx, "my_feature", false, evalCtx); err != nil {
+		t.Errorf("Expected attributes Split ignores not to fail the evaluation, got %v (%+v)", err, details)
+	}
+}
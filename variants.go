@@ -0,0 +1,186 @@
+package split_openfeature_provider_go
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/splitio/go-toolkit/v5/logging"
+)
+
+// VariantConstraints declares the treatments flags may serve, configured with WithAllowedVariants.
+type VariantConstraints struct {
+	// Flags maps flags to their allowed treatments.
+	Flags map[string][]string
+	// FromDefinitions lists flags whose allowed treatments are the treatments of their Split
+	// definition (see SplitProvider.Flag). The list is kept per change number of the definition and
+	// read again when the change number differs, so treatments added in Split are allowed as soon
+	// as the SDK sees them. Evaluations whose definition cannot be read are rejected. Providers
+	// built with NewProvider need WithManager.
+	FromDefinitions []string
+	// Logger receives an error for every rejected treatment. When nil, a Split SDK logger with
+	// default options is used.
+	Logger logging.LoggerInterface
+}
+
+// WithAllowedVariants constrains flags to a closed set of treatments: evaluations of them that
+// resolve to any other treatment, e.g. one mistyped in Split, return the default value with
+// PARSE_ERROR and are logged. This applies to the *Evaluation methods and Evaluate alike, including
+// fallback treatments.
+func WithAllowedVariants(constraints VariantConstraints) Option {
+	return func(o *providerOptions) {
+		flags := make(map[string][]string, len(constraints.Flags))
+		for flag, variants := range constraints.Flags {
+			flags[flag] = append([]string(nil), variants...)
+		}
+		constraints.Flags = flags
+		constraints.FromDefinitions = append([]string(nil), constraints.FromDefinitions...)
+		o.variants = &constraints
+	}
+}
+
+// errVariantsNeedManager is returned by NewProvider for VariantConstraints.FromDefinitions without
+// WithManager.
+var errVariantsNeedManager = errors.New("allowed variants read from definitions need the Split manager, use WithManager")
+
+func (c *VariantConstraints) validate() error {
+	if c == nil {
+		return nil
+	}
+	for flag, variants := range c.Flags {
+		if len(variants) == 0 {
+			return fmt.Errorf("allowed variants of flag %s cannot be empty", flag)
+		}
+	}
+	for _, flag := range c.FromDefinitions {
+		if _, ok := c.Flags[flag]; ok {
+			return fmt.Errorf("allowed variants of flag %s are both listed and read from its definition", flag)
+		}
+	}
+	return nil
+}
+
+// variantSet is a set of allowed treatments.
+type variantSet map[string]struct{}
+
+func newVariantSet(variants []string) variantSet {
+	set := make(variantSet, len(variants))
+	for _, variant := range variants {
+		set[variant] = struct{}{}
+	}
+	return set
+}
+
+// variantGuard rejects treatments outside the allowed variants of their flag.
+type variantGuard struct {
+	provider *SplitProvider
+	logger   logging.LoggerInterface
+	// static holds the variants listed in VariantConstraints.Flags.
+	static map[string]variantSet
+	// fromDefinitions holds the flags whose variants are read from their definition.
+	fromDefinitions map[string]struct{}
+
+	mu sync.RWMutex
+	// generation is increased by invalidate so that definitions read before an invalidation are
+	// not stored after it.
+	generation uint64
+	// derived holds the variants read from definitions so far.
+	derived map[string]derivedVariants
+}
+
+// derivedVariants are the variants read from a definition and its change number.
+type derivedVariants struct {
+	changeNumber int64
+	allowed      variantSet
+}
+
+func newVariantGuard(p *SplitProvider, c *VariantConstraints) *variantGuard {
+	if c == nil {
+		return nil
+	}
+	g := &variantGuard{
+		provider:        p,
+		logger:          c.Logger,
+		static:          make(map[string]variantSet, len(c.Flags)),
+		fromDefinitions: make(map[string]struct{}, len(c.FromDefinitions)),
+		derived:         make(map[string]derivedVariants, len(c.FromDefinitions)),
+	}
+	if g.logger == nil {
+		g.logger = logging.NewLogger(nil)
+	}
+	for flag, variants := range c.Flags {
+		g.static[flag] = newVariantSet(variants)
+	}
+	for _, flag := range c.FromDefinitions {
+		g.fromDefinitions[flag] = struct{}{}
+	}
+	return g
+}
+
+// check returns a PARSE_ERROR detail and true when treatment is not an allowed variant of flag.
+// Flags without constraints allow every treatment; flags read from definitions allow none while
+// their definition cannot be read.
+func (g *variantGuard) check(flag, treatment string) (openfeature.ProviderResolutionDetail, bool) {
+	if g == nil {
+		return openfeature.ProviderResolutionDetail{}, false
+	}
+	allowed, constrained, err := g.variants(flag)
+	if !constrained {
+		return openfeature.ProviderResolutionDetail{}, false
+	}
+	message := fmt.Sprintf("treatment %q is not an allowed variant of flag %s", treatment, flag)
+	if err != nil {
+		message = fmt.Sprintf("allowed variants of flag %s are unknown: %v", flag, err)
+	} else if _, ok := allowed[treatment]; ok {
+		return openfeature.ProviderResolutionDetail{}, false
+	}
+	g.logger.Error(message)
+	return openfeature.ProviderResolutionDetail{
+		ResolutionError: openfeature.NewParseErrorResolutionError(message),
+		Reason:          openfeature.ErrorReason,
+		Variant:         treatment,
+	}, true
+}
+
+// variants returns the allowed variants of flag and whether it is constrained at all, or an error
+// when they must be read from a definition that cannot be read.
+func (g *variantGuard) variants(flag string) (variantSet, bool, error) {
+	if allowed, ok := g.static[flag]; ok {
+		return allowed, true, nil
+	}
+	if _, ok := g.fromDefinitions[flag]; !ok {
+		return nil, false, nil
+	}
+	changeNumber := g.provider.changeNumber(flag)
+	g.mu.RLock()
+	derived, ok := g.derived[flag]
+	generation := g.generation
+	g.mu.RUnlock()
+	if ok && derived.changeNumber == changeNumber {
+		return derived.allowed, true, nil
+	}
+	definition, err := g.provider.Flag(flag)
+	if err != nil {
+		return nil, true, err
+	}
+	derived = derivedVariants{changeNumber: definition.ChangeNumber, allowed: newVariantSet(definition.Treatments)}
+	g.mu.Lock()
+	if generation == g.generation {
+		g.derived[flag] = derived
+	}
+	g.mu.Unlock()
+	return derived.allowed, true, nil
+}
+
+// invalidate discards the variants read from definitions, so they are read again even when their
+// change number is unchanged.
+func (g *variantGuard) invalidate() {
+	if g == nil {
+		return
+	}
+	g.mu.Lock()
+	g.generation++
+	clear(g.derived)
+	g.mu.Unlock()
+}
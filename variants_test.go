@@ -0,0 +1,157 @@
+package split_openfeature_provider_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// errorLogger records the messages logged at error level.
+type errorLogger struct {
+	mu     sync.Mutex
+	errors []string
+}
+
+func (l *errorLogger) Error(msg ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errors = append(l.errors, fmt.Sprint(msg...))
+}
+
+func (l *errorLogger) Warning(...interface{}) {}
+func (l *errorLogger) Info(...interface{})    {}
+func (l *errorLogger) Debug(...interface{})   {}
+func (l *errorLogger) Verbose(...interface{}) {}
+
+func (l *errorLogger) logged() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.errors...)
+}
+
+func TestWithAllowedVariants(t *testing.T) {
+	logger := &errorLogger{}
+	provider, err := NewLocalhostProviderFromDefinitions([]LocalFlag{
+		{Name: "checkout", Treatment: "v3", Keys: []string{"typo"}},
+		{Name: "checkout", Treatment: "v2"},
+		{Name: "banner", Treatment: "blue"},
+	}, quietSDK(), WithAllowedVariants(VariantConstraints{
+		Flags:  map[string][]string{"checkout": {"v1", "v2"}},
+		Logger: logger,
+	}), WithTreatmentDecoder[checkoutVersion](checkoutVersionDecoder))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+	ctx := context.Background()
+
+	if result := provider.StringEvaluation(ctx, "checkout", "v1", openfeature.FlattenedContext{openfeature.TargetingKey: "user"}); result.Value != "v2" {
+		t.Errorf("Expected the allowed variant v2, got %+v", result)
+	}
+	typo := openfeature.FlattenedContext{openfeature.TargetingKey: "typo"}
+	result := provider.StringEvaluation(ctx, "checkout", "v1", typo)
+	if result.Value != "v1" || result.Variant != "v3" || result.ResolutionDetail().ErrorCode != openfeature.ParseErrorCode {
+		t.Errorf("Expected the default with %s for v3, got %+v", openfeature.ParseErrorCode, result)
+	}
+	if typed := Evaluate(ctx, provider, "checkout", checkoutV1, openfeature.NewEvaluationContext("typo", nil)); typed.ResolutionDetail().ErrorCode != openfeature.ParseErrorCode {
+		t.Errorf("Expected Evaluate to apply the constraint, got %+v", typed)
+	}
+	if result := provider.StringEvaluation(ctx, "banner", "", typo); result.Value != "blue" {
+		t.Errorf("Expected unconstrained flags to be served verbatim, got %+v", result)
+	}
+
+	logged := logger.logged()
+	if len(logged) != 2 || !strings.Contains(logged[0], `"v3"`) || !strings.Contains(logged[0], "checkout") {
+		t.Errorf("Expected the rejected treatments to be logged, got %v", logged)
+	}
+}
+
+func TestWithAllowedVariants_FromDefinitions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "split.yaml")
+	if err := os.WriteFile(path, []byte("- checkout:\n    treatment: \"v1\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	provider, err := NewLocalhostProvider(path, quietSDK(), WithLocalhostFileWatch(20*time.Millisecond),
+		WithFallbackTreatments(FallbackTreatments{ByFlag: map[string]FallbackTreatment{"missing": {Treatment: "off"}}}),
+		WithAllowedVariants(VariantConstraints{FromDefinitions: []string{"checkout", "missing"}, Logger: &errorLogger{}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+	ctx := context.Background()
+	user := openfeature.FlattenedContext{openfeature.TargetingKey: "user"}
+
+	if result := provider.StringEvaluation(ctx, "checkout", "", user); result.Value != "v1" {
+		t.Fatalf("Expected v1, got %+v", result)
+	}
+	if result := provider.StringEvaluation(ctx, "missing", "default", user); result.Value != "default" || result.ResolutionDetail().ErrorCode != openfeature.ParseErrorCode {
+		t.Errorf("Expected flags without a readable definition to be rejected, got %+v", result)
+	}
+
+	if err := os.WriteFile(path, []byte("- checkout:\n    treatment: \"v2\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	waitForEvent(t, provider.EventChannel())
+	if result := provider.StringEvaluation(ctx, "checkout", "v1", user); result.Value != "v2" || result.ResolutionError != (openfeature.ResolutionError{}) {
+		t.Errorf("Expected treatments added by a configuration change to be allowed, got %+v", result)
+	}
+}
+
+func TestWithAllowedVariants_FromDefinitionsChangeNumber(t *testing.T) {
+	server := miniredis.RunT(t)
+	synchronize(t, server, "myapp")
+	provider, err := NewRedisConsumerProvider(context.Background(), "api-key", redisConfigFor(t, server, "myapp"), quietSDK(),
+		WithAllowedVariants(VariantConstraints{FromDefinitions: []string{"checkout"}, Logger: &errorLogger{}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown()
+	ctx := context.Background()
+	user := openfeature.FlattenedContext{openfeature.TargetingKey: "user"}
+
+	if result := provider.StringEvaluation(ctx, "checkout", "", user); result.Value != "v1" {
+		t.Fatalf("Expected v1, got %+v", result)
+	}
+	updated := strings.NewReplacer(`"defaultTreatment": "v1", "changeNumber": 1`, `"defaultTreatment": "v2", "changeNumber": 2`).Replace(redisCheckoutFlag)
+	if err := server.Set("myapp.SPLITIO.split.checkout", updated); err != nil {
+		t.Fatal(err)
+	}
+	if result := provider.StringEvaluation(ctx, "checkout", "", user); result.Value != "v2" || result.ResolutionError != (openfeature.ResolutionError{}) {
+		t.Errorf("Expected a treatment added without a configuration change event to be allowed, got %+v", result)
+	}
+}
+
+func TestWithAllowedVariants_FromDefinitionsNeedsManager(t *testing.T) {
+	localhost, err := NewLocalhostProviderFromDefinitions([]LocalFlag{{Name: "checkout", Treatment: "on"}}, quietSDK())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer localhost.Shutdown()
+	constraints := WithAllowedVariants(VariantConstraints{FromDefinitions: []string{"checkout"}})
+	if _, err := NewProvider(localhost.currentClient(), constraints); !errors.Is(err, errVariantsNeedManager) {
+		t.Errorf("Expected %v, got %v", errVariantsNeedManager, err)
+	}
+	if _, err := NewProvider(localhost.currentClient(), WithAllowedVariants(VariantConstraints{Flags: map[string][]string{"checkout": {"on"}}})); err != nil {
+		t.Errorf("Expected listed variants not to need a manager, got %v", err)
+	}
+}
+
+func TestWithAllowedVariants_Validation(t *testing.T) {
+	for _, constraints := range []VariantConstraints{
+		{Flags: map[string][]string{"checkout": nil}},
+		{Flags: map[string][]string{"checkout": {"v1"}}, FromDefinitions: []string{"checkout"}},
+	} {
+		if _, err := newProviderOptions([]Option{WithAllowedVariants(constraints)}); err == nil {
+			t.Errorf("Expected an error for %+v", constraints)
+		}
+	}
+}